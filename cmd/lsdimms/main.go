@@ -17,14 +17,68 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/lijingwei9060/go-smbios/smbios"
 )
 
 func main() {
+	jsonOut := flag.Bool("json", false, "dump all of the parsed SMBIOS data as JSON")
+	flag.Parse()
+
 	out := smbios.GetSMBIOS()
-	str, _ := json.Marshal(out)
-	fmt.Print(string(str))
 
+	if *jsonOut {
+		if err := json.NewEncoder(os.Stdout).Encode(out); err != nil {
+			log.Fatalf("failed to encode JSON: %v", err)
+		}
+		return
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	defer tw.Flush()
+
+	fmt.Fprintln(tw, "LOCATOR\tSIZE\tTYPE\tSPEED\tMANUFACTURER\tPART NUMBER\tSERIAL NUMBER\tRCD\tPMIC")
+
+	// Systems predating SMBIOS 2.1 only report the legacy Type 6 memory
+	// modules, so fall back to those when no memory devices are present.
+	if len(out.MemoryDevices) == 0 {
+		for _, m := range out.MemoryModules {
+			size := m.InstalledSizeStatus
+			if size == "" {
+				size = fmt.Sprintf("%d MB", m.InstalledSize)
+			}
+			speed := "Unknown"
+			if m.CurrentSpeed != 0 {
+				speed = fmt.Sprintf("%d ns", m.CurrentSpeed)
+			}
+
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t\t\t\t\t\n",
+				m.SocketDesignation, size, strings.Join(m.CurrentMemoryType, " "), speed)
+		}
+		return
+	}
+
+	for _, d := range out.MemoryDevices {
+		if d == nil {
+			continue
+		}
+
+		// Only DDR5 and later modules report RCD and PMIC manufacturers.
+		var rcd, pmic string
+		if d.RCDManufacturerID != 0 {
+			rcd = d.RCDManufacturer()
+		}
+		if d.PMIC0ManufacturerID != 0 {
+			pmic = d.PMIC0Manufacturer()
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			d.DeviceLocator, d.Size, d.MemoryType, d.Speed, d.ModuleManufacturer(), d.PartNumber, d.SerialNumber, rcd, pmic)
+	}
 }
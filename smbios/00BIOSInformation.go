@@ -11,16 +11,16 @@ type BIOSInformation struct { // type 0
 	BIOSVersion                string // 5 String number
 	BIOSStartingAddressSegment uint16 // 6-7
 	BIOSReleaseDate            string // 8 String number
-	// Size (n) where 64K * (n+1) is the size of the physical device containing the BIOS, in bytes.
-	// FFh - size is 16MB or greater, see Extended BIOS ROM Size for actual size
-	BIOSROMSize                            int      // 9
-	BIOSCharacteristics                    []string // 10-17
-	BIOSCharacteristicsExtensionBytes      uint16   // 18-19 2.4+
-	SystemBIOSMajorRelease                 uint8    // 20
-	SystemBIOSMinorRelease                 uint8    // 21
-	EmbeddedControllerFirmwareMajorRelease uint8    // 22
-	EmbeddedControllerFirmwareMinorRelease uint8    // 23
-	ExtendedBIOSROMSize                    uint16   // 24
+	// BIOSROMSize is the size of the physical device containing the BIOS,
+	// decoded from the Extended BIOS ROM Size when the device is 16MB or greater.
+	BIOSROMSize                            Bytes                        // 9
+	BIOSCharacteristics                    BIOSCharacteristics          // 10-17 7.1.1
+	BIOSCharacteristicsExtensionBytes      BIOSCharacteristicsExtension // 18-19 7.1.2 2.4+
+	SystemBIOSMajorRelease                 uint8                        // 20
+	SystemBIOSMinorRelease                 uint8                        // 21
+	EmbeddedControllerFirmwareMajorRelease uint8                        // 22
+	EmbeddedControllerFirmwareMinorRelease uint8                        // 23
+	ExtendedBIOSROMSize                    uint16                       // 24
 }
 
 // ParseBIOSInformation 解析structure
@@ -47,42 +47,41 @@ func ParseBIOSInformation(s *Structure) (*BIOSInformation, error) {
 		ret.BIOSReleaseDate = strings.TrimSpace(s.Strings[2])
 	}
 
-	// unit :KB
-	BIOSROMSize := uint8(s.Formatted[5])
-	if BIOSROMSize != 0xff || s.Header.Length <= 24 { // 小于版本3.1或者小于16M
-		ret.BIOSROMSize = (int(s.Formatted[5]) + 1) * 64
-	} else {
-		ret.BIOSROMSize = (int(binary.LittleEndian.Uint16(s.Formatted[20:22])) + 1) * 64
-	}
+	// 64K * (n+1)，FFh 表示 16MB 及以上，见 Extended BIOS ROM Size
+	ret.BIOSROMSize = (Bytes(s.Formatted[5]) + 1) * 64 * KiB
 
-	BIOSCharacteristics := binary.LittleEndian.Uint64(s.Formatted[6:14])
-	bit := uint64(0x01)
-	for i := uint(0); i <= 31; i++ {
-		if (BIOSCharacteristics>>i)&bit == bit {
-			ret.BIOSCharacteristics = append(ret.BIOSCharacteristics, BIOSInformationCharacter[i])
-		}
-	}
+	ret.BIOSCharacteristics = BIOSCharacteristics(binary.LittleEndian.Uint64(s.Formatted[6:14]))
 	if s.Header.Length > 18 { // 2.4+
-		ceb := binary.LittleEndian.Uint16(s.Formatted[14:16])
-		bit16 := uint16(0x01)
-		for i := uint(0); i <= 12; i++ {
-			if (ceb>>i)&bit16 == bit16 {
-				ret.BIOSCharacteristics = append(ret.BIOSCharacteristics, BIOSCharacteristicsExtensionBytes[i])
-			}
-		}
-		ret.BIOSCharacteristicsExtensionBytes = binary.LittleEndian.Uint16(s.Formatted[14:16])
+		ret.BIOSCharacteristicsExtensionBytes = BIOSCharacteristicsExtension(binary.LittleEndian.Uint16(s.Formatted[14:16]))
 		ret.SystemBIOSMajorRelease = uint8(s.Formatted[16])
 		ret.SystemBIOSMinorRelease = uint8(s.Formatted[17])
 		ret.EmbeddedControllerFirmwareMajorRelease = uint8(s.Formatted[18])
 		ret.EmbeddedControllerFirmwareMinorRelease = uint8(s.Formatted[19])
 	}
-	if s.Header.Length > 24 { // 3.1+
+	if len(s.Formatted) >= 22 { // 3.1+
 		ret.ExtendedBIOSROMSize = binary.LittleEndian.Uint16(s.Formatted[20:22])
+		if s.Formatted[5] == 0xFF {
+			ret.BIOSROMSize = getExtendedBIOSROMSize(ret.ExtendedBIOSROMSize)
+		}
 	}
 
 	return ret, nil
 }
 
+// getExtendedBIOSROMSize 解析 Extended BIOS ROM Size：bit 15:14 是单位，
+// 00b 为 MB，01b 为 GB，其他保留；bit 13:0 是大小
+func getExtendedBIOSROMSize(n uint16) Bytes {
+	size := Bytes(n & 0x3FFF)
+	switch n >> 14 {
+	case 0:
+		return size * MiB
+	case 1:
+		return size * GiB
+	default:
+		return UnknownBytes
+	}
+}
+
 var BIOSInformationCharacter = []string{
 	"Reserved",                           /* bit 0 */
 	"Reserved",                           /* bit 1 */
@@ -131,5 +130,145 @@ var BIOSCharacteristicsExtensionBytes = []string{
 	"Function key-initiated network boot is supported",
 	"Targeted content distribution is supported",
 	"UEFI is supported",
-	"System is a virtual machine", /* bit 12 */
+	"System is a virtual machine",     /* bit 12 */
+	"Manufacturing mode is supported", /* bit 13 3.5+ */
+	"Manufacturing mode is enabled",   /* bit 14 */
+}
+
+// BIOSCharacteristics 是 BIOS 特性位（7.1.1），保留了 32-47 位 BIOS 厂商
+// 和 48-63 位系统厂商自定义的位
+type BIOSCharacteristics uint64
+
+// BIOS 特性位
+const (
+	BIOSCharUnknown BIOSCharacteristics = 1 << (iota + 2)
+	BIOSCharNotSupported
+	BIOSCharISA
+	BIOSCharMCA
+	BIOSCharEISA
+	BIOSCharPCI
+	BIOSCharPCCard
+	BIOSCharPNP
+	BIOSCharAPM
+	BIOSCharUpgradeable
+	BIOSCharShadowing
+	BIOSCharVLB
+	BIOSCharESCD
+	BIOSCharBootFromCD
+	BIOSCharSelectableBoot
+	BIOSCharROMSocketed
+	BIOSCharBootFromPCCard
+	BIOSCharEDD
+	BIOSCharFloppyNEC9800
+	BIOSCharFloppyToshiba
+	BIOSCharFloppy525360K
+	BIOSCharFloppy52512M
+	BIOSCharFloppy35720K
+	BIOSCharFloppy35288M
+	BIOSCharPrintScreen
+	BIOSCharKeyboard8042
+	BIOSCharSerial
+	BIOSCharPrinter
+	BIOSCharCGAMono
+	BIOSCharNECPC98 // bit 31
+)
+
+var biosCharacteristics = newFlagSet("BIOSCharacteristics", 64, func() []string {
+	names := append([]string(nil), BIOSInformationCharacter...)
+	for i := 32; i < 64; i++ {
+		if i < 48 {
+			names = append(names, fmt.Sprintf("BIOS vendor reserved (bit %d)", i))
+		} else {
+			names = append(names, fmt.Sprintf("System vendor reserved (bit %d)", i))
+		}
+	}
+	return names
+}())
+
+// Has 返回是否设置了 flag 中的所有位
+func (c BIOSCharacteristics) Has(flag BIOSCharacteristics) bool { return c&flag == flag }
+
+// Flags 把设置的每一位作为单独的值返回
+func (c BIOSCharacteristics) Flags() []BIOSCharacteristics {
+	var out []BIOSCharacteristics
+	for _, b := range biosCharacteristics.bits(uint64(c)) {
+		out = append(out, BIOSCharacteristics(b))
+	}
+	return out
+}
+
+// Strings 返回设置的每一位的名称
+func (c BIOSCharacteristics) Strings() []string { return biosCharacteristics.strings(uint64(c)) }
+
+// String implements fmt.Stringer.
+func (c BIOSCharacteristics) String() string { return strings.Join(c.Strings(), ", ") }
+
+// MarshalJSON implements json.Marshaler.
+func (c BIOSCharacteristics) MarshalJSON() ([]byte, error) {
+	return biosCharacteristics.marshalJSON(uint64(c))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *BIOSCharacteristics) UnmarshalJSON(b []byte) error {
+	v, err := biosCharacteristics.unmarshalJSON(b)
+	*c = BIOSCharacteristics(v)
+	return err
+}
+
+// BIOSCharacteristicsExtension 是 BIOS 特性扩展字节（7.1.2）
+type BIOSCharacteristicsExtension uint16
+
+// BIOS 特性扩展位
+const (
+	BIOSExtACPI BIOSCharacteristicsExtension = 1 << iota
+	BIOSExtUSBLegacy
+	BIOSExtAGP
+	BIOSExtI2OBoot
+	BIOSExtLS120Boot
+	BIOSExtATAPIZipBoot
+	BIOSExt1394Boot
+	BIOSExtSmartBattery
+	BIOSExtBootSpecification
+	BIOSExtNetworkBoot
+	BIOSExtTargetedContent
+	BIOSExtUEFI
+	BIOSExtVirtualMachine
+	BIOSExtManufacturingModeSupported
+	BIOSExtManufacturingModeEnabled // bit 14
+)
+
+var biosCharacteristicsExtension = newFlagSet("BIOSCharacteristicsExtension", 16, BIOSCharacteristicsExtensionBytes)
+
+// Has 返回是否设置了 flag 中的所有位
+func (c BIOSCharacteristicsExtension) Has(flag BIOSCharacteristicsExtension) bool {
+	return c&flag == flag
+}
+
+// Flags 把设置的每一位作为单独的值返回
+func (c BIOSCharacteristicsExtension) Flags() []BIOSCharacteristicsExtension {
+	var out []BIOSCharacteristicsExtension
+	for _, b := range biosCharacteristicsExtension.bits(uint64(c)) {
+		out = append(out, BIOSCharacteristicsExtension(b))
+	}
+	return out
+}
+
+// Strings 返回设置的每一位的名称
+func (c BIOSCharacteristicsExtension) Strings() []string {
+	return biosCharacteristicsExtension.strings(uint64(c))
+}
+
+// String implements fmt.Stringer.
+func (c BIOSCharacteristicsExtension) String() string { return strings.Join(c.Strings(), ", ") }
+
+// MarshalJSON implements json.Marshaler.
+func (c BIOSCharacteristicsExtension) MarshalJSON() ([]byte, error) {
+	return biosCharacteristicsExtension.marshalJSON(uint64(c))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *BIOSCharacteristicsExtension) UnmarshalJSON(b []byte) error {
+	v, err := biosCharacteristicsExtension.unmarshalJSON(b)
+	*c = BIOSCharacteristicsExtension(v)
+	return err
 }
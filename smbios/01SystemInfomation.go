@@ -6,14 +6,14 @@ import (
 )
 
 type SystemInformation struct {
-	Manufacturer string // 4 String number
-	ProductName  string // 5 String number
-	Version      string // 6 String number
-	SerialNumber string // 7 String number
-	UUID         string // 8-23 UUID 16bytes 2.1+
-	WakeUpType   string // 24
-	SKUNumber    string // 25 String number 2.4+
-	Family       string //26 Stringnumber
+	Manufacturer string     // 4 String number
+	ProductName  string     // 5 String number
+	Version      string     // 6 String number
+	SerialNumber string     // 7 String number
+	UUID         string     // 8-23 UUID 16bytes 2.1+
+	WakeUpType   WakeUpType // 24 7.2.2
+	SKUNumber    string     // 25 String number 2.4+
+	Family       string     //26 Stringnumber
 }
 
 func ParseSystemInformation(s *Structure) (*SystemInformation, error) { // type 1
@@ -50,12 +50,7 @@ func ParseSystemInformation(s *Structure) (*SystemInformation, error) { // type
 	ret.UUID = fmt.Sprintf("%02x%02x%02x%02x-%02x%02x-%02x%02x-%02x%02x-%02x%02x%02x%02x%02x%02x", p[3], p[2], p[1], p[0], p[5], p[4], p[7], p[6],
 		p[8], p[9], p[10], p[11], p[12], p[13], p[14], p[15])
 
-	w := int(s.Formatted[20])
-	if w > 0 && w < len(wakeUpType) {
-		ret.WakeUpType = wakeUpType[w]
-	} else {
-		ret.WakeUpType = wakeUpType[2]
-	}
+	ret.WakeUpType = WakeUpType(s.Formatted[20])
 
 	if len(s.Strings) >= 5 {
 		ret.SKUNumber = strings.TrimSpace(s.Strings[4])
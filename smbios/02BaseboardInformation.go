@@ -3,19 +3,20 @@ package smbios
 import (
 	"encoding/binary"
 	"fmt"
+	"strings"
 )
 
 type BaseboardInformation struct { // 7.3 type 2
-	Manufacturer                   string   // 4 String number
-	ProductName                    string   // 5 String number
-	Version                        string   // 6 String number
-	SerialNumber                   string   // 7 String number
-	AssetTag                       string   // 8 String number
-	FeatureFlags                   []string //9
-	LocationInChassis              string   // 10 String number
-	ChassisHandle                  uint16   // 11-12
-	BoardType                      string   // 13
-	NumberOfContainedObjectHandles uint8    // 14
+	Manufacturer                   string            // 4 String number
+	ProductName                    string            // 5 String number
+	Version                        string            // 6 String number
+	SerialNumber                   string            // 7 String number
+	AssetTag                       string            // 8 String number
+	FeatureFlags                   BaseboardFeatures // 9 7.3.1
+	LocationInChassis              string            // 10 String number
+	ChassisHandle                  uint16            // 11-12
+	BoardType                      BoardType         // 13 7.3.2
+	NumberOfContainedObjectHandles uint8             // 14
 	//ContainedObjectHandles
 }
 
@@ -44,24 +45,12 @@ func ParseBaseboardInformation(s *Structure) (*BaseboardInformation, error) { //
 	if len(s.Strings) >= 5 {
 		ret.AssetTag = s.Strings[4]
 	}
-	// features
-	featureflag := uint8(s.Formatted[9])
-	bit := uint8(0x01)
-	for i := uint8(0); i < uint8(len(bifeatures)); i++ {
-		if (featureflag>>i)&bit == bit {
-			ret.FeatureFlags = append(ret.FeatureFlags, bifeatures[i])
-		}
-	}
+	ret.FeatureFlags = BaseboardFeatures(s.Formatted[5])
 	if len(s.Strings) >= 6 {
 		ret.LocationInChassis = s.Strings[5]
 	}
 	ret.ChassisHandle = binary.LittleEndian.Uint16(s.Formatted[7:9])
-	b := int(s.Formatted[9])
-	if b > 0 && b < len(bitypes) {
-		ret.BoardType = bitypes[b]
-	} else {
-		ret.BoardType = bitypes[0]
-	}
+	ret.BoardType = BoardType(s.Formatted[9])
 	ret.NumberOfContainedObjectHandles = uint8(s.Formatted[10])
 
 	return ret, nil
@@ -76,6 +65,50 @@ var bifeatures = []string{
 	"Board is hot swappable", /* bit 4 */
 }
 
+// BaseboardFeatures 是主板特性位（7.3.1）
+type BaseboardFeatures uint8
+
+// 主板特性位
+const (
+	BaseboardHostingBoard BaseboardFeatures = 1 << iota
+	BaseboardRequiresDaughterBoard
+	BaseboardRemovable
+	BaseboardReplaceable
+	BaseboardHotSwappable // bit 4
+)
+
+var baseboardFeatures = newFlagSet("BaseboardFeatures", 8, bifeatures)
+
+// Has 返回是否设置了 flag 中的所有位
+func (f BaseboardFeatures) Has(flag BaseboardFeatures) bool { return f&flag == flag }
+
+// Flags 把设置的每一位作为单独的值返回
+func (f BaseboardFeatures) Flags() []BaseboardFeatures {
+	var out []BaseboardFeatures
+	for _, b := range baseboardFeatures.bits(uint64(f)) {
+		out = append(out, BaseboardFeatures(b))
+	}
+	return out
+}
+
+// Strings 返回设置的每一位的名称
+func (f BaseboardFeatures) Strings() []string { return baseboardFeatures.strings(uint64(f)) }
+
+// String implements fmt.Stringer.
+func (f BaseboardFeatures) String() string { return strings.Join(f.Strings(), ", ") }
+
+// MarshalJSON implements json.Marshaler.
+func (f BaseboardFeatures) MarshalJSON() ([]byte, error) {
+	return baseboardFeatures.marshalJSON(uint64(f))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *BaseboardFeatures) UnmarshalJSON(b []byte) error {
+	v, err := baseboardFeatures.unmarshalJSON(b)
+	*f = BaseboardFeatures(v)
+	return err
+}
+
 /* 7.3.2 */
 var bitypes = []string{
 	"Unknown", /* 0x00 */
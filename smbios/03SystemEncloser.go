@@ -6,22 +6,23 @@ import (
 )
 
 type SystemEnclosure struct { // 7.4 type 3
-	Manufacturer                 string // 4 String number
-	Type                         string // 5
-	Version                      string // 6 String number
-	SerialNumber                 string // 7 String number
-	AssetTag                     string // 8 String number
-	BootUpState                  string // 9 7.4.2 2.1+
-	PowerSupplyState             string // 10 7.4.2
-	ThermalState                 string // 11 7.4.2
-	SecurityStatus               string // 12 7.4.3
-	OEMDefined                   uint32 // 13-16 2.3+
-	Height                       uint8  // 17 uint:U 1.75inches or 4.445 cm
-	NumberOfPowerCords           uint8  // 18
-	ContainedElementCount        uint8  // 19 n
-	ContainedElementRecordLength uint8  // 20 m
-	ContainedElements            int    // n*m 7.4.4
-	SKUNumber                    string // String number 2.7+
+	Manufacturer                 string                // 4 String number
+	Type                         ChassisType           // 5 7.4.1 bit 6:0
+	ChassisLockPresent           bool                  // 5 bit 7
+	Version                      string                // 6 String number
+	SerialNumber                 string                // 7 String number
+	AssetTag                     string                // 8 String number
+	BootUpState                  ChassisState          // 9 7.4.2 2.1+
+	PowerSupplyState             ChassisState          // 10 7.4.2
+	ThermalState                 ChassisState          // 11 7.4.2
+	SecurityStatus               ChassisSecurityStatus // 12 7.4.3
+	OEMDefined                   uint32                // 13-16 2.3+
+	Height                       uint8                 // 17 uint:U 1.75inches or 4.445 cm
+	NumberOfPowerCords           uint8                 // 18
+	ContainedElementCount        uint8                 // 19 n
+	ContainedElementRecordLength uint8                 // 20 m
+	ContainedElements            int                   // n*m 7.4.4
+	SKUNumber                    string                // String number 2.7+
 }
 
 func ParseSystemEnclosure(s *Structure) (*SystemEnclosure, error) {
@@ -36,13 +37,8 @@ func ParseSystemEnclosure(s *Structure) (*SystemEnclosure, error) {
 	if len(s.Strings) >= 1 {
 		ret.Manufacturer = s.Strings[0]
 	}
-	var t int // 临时变量
-	t = int(s.Formatted[1])
-	if t > 0 && t <= len(Chassis_Type) {
-		ret.Type = Chassis_Type[t-1]
-	} else {
-		ret.Type = Chassis_Type[1]
-	}
+	ret.Type = ChassisType(s.Formatted[1] & 0x7F)
+	ret.ChassisLockPresent = s.Formatted[1]&0x80 != 0
 
 	if len(s.Strings) >= 2 {
 		ret.Version = s.Strings[1]
@@ -54,33 +50,10 @@ func ParseSystemEnclosure(s *Structure) (*SystemEnclosure, error) {
 		ret.AssetTag = s.Strings[3]
 	}
 
-	t = int(s.Formatted[5])
-	if t > 0 && t <= len(Chassis_State) {
-		ret.BootUpState = Chassis_State[t-1]
-	} else {
-		ret.BootUpState = Chassis_State[1]
-	}
-
-	t = int(s.Formatted[6])
-	if t > 0 && t <= len(Chassis_State) {
-		ret.PowerSupplyState = Chassis_State[t-1]
-	} else {
-		ret.PowerSupplyState = Chassis_State[1]
-	}
-
-	t = int(s.Formatted[7])
-	if t > 0 && t <= len(Chassis_State) {
-		ret.ThermalState = Chassis_State[t-1]
-	} else {
-		ret.ThermalState = Chassis_State[1]
-	}
-
-	t = int(s.Formatted[8])
-	if t > 0 && t <= len(Chassis_Security_State) {
-		ret.SecurityStatus = Chassis_Security_State[t-1]
-	} else {
-		ret.SecurityStatus = Chassis_Security_State[1]
-	}
+	ret.BootUpState = ChassisState(s.Formatted[5])
+	ret.PowerSupplyState = ChassisState(s.Formatted[6])
+	ret.ThermalState = ChassisState(s.Formatted[7])
+	ret.SecurityStatus = ChassisSecurityStatus(s.Formatted[8])
 	ret.OEMDefined = binary.LittleEndian.Uint32(s.Formatted[9:13])
 	ret.Height = uint8(s.Formatted[13])
 	ret.NumberOfPowerCords = uint8(s.Formatted[14])
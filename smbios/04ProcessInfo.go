@@ -3,36 +3,37 @@ package smbios
 import (
 	"encoding/binary"
 	"fmt"
+	"strings"
 )
 
 // TODO: 涉及到的参数太复杂，现在先不做太详细，很多细节没处理
 type ProcessorInformation struct { // 7.5 type 4
-	SocketDesignation        string   // 4 String number
-	ProcessorType            string   // 5 7.5.1
-	ProcessorFamily          string   // 6 7.5.2
-	ProcessorManufacturer    string   // 7 String number
-	ProcessorID              string   // 8-15 7.5.3
-	ProcessorVersion         string   // 16 String number
-	Voltage                  string   // 17 7.5.4
-	ExternalClock            uint16   // 18-19
-	MaxSpeed                 int      // 20-21 uint:MHz
-	CurrentSpeed             int      // 22-23 uint:MHz
-	Status                   uint8    // 24
-	ProcessorUpgrade         string   // 25 7.5.5
-	L1CacheHandle            uint16   // 26-27 2.1+
-	L2CacheHandle            uint16   // 28-29
-	L3CacheHandle            uint16   // 30-31 2.3+
-	SerialNumber             string   // 32 String number
-	AssetTag                 string   // 33 String number
-	PartNumber               string   // 34 String number
-	CoreCount                int      // 35 7.5.6 2.5+
-	CoreEnabled              int      // 36 7.5.7
-	ThreadCount              int      // 37 7.5.8
-	ProcessorCharacteristics []string // 38-39  7.5.9
-	ProcessorFamily2         string   // 40-41 7.5.2 2.6+
-	CoreCount2               int      // 42-43 7.5.6 3.0+
-	CoreEnabled2             int      // 44-45 7.5.7
-	ThreadCount2             int      // 46-47 7.5.8
+	SocketDesignation        string                   // 4 String number
+	ProcessorType            ProcessorType            // 5 7.5.1
+	ProcessorFamily          ProcessorFamily          // 6 7.5.2
+	ProcessorManufacturer    string                   // 7 String number
+	ProcessorID              ProcessorID              // 8-15 7.5.3
+	ProcessorVersion         string                   // 16 String number
+	Voltage                  string                   // 17 7.5.4
+	ExternalClock            Megahertz                // 18-19
+	MaxSpeed                 Megahertz                // 20-21
+	CurrentSpeed             Megahertz                // 22-23
+	Status                   uint8                    // 24
+	ProcessorUpgrade         ProcessorUpgrade         // 25 7.5.5
+	L1CacheHandle            uint16                   // 26-27 2.1+
+	L2CacheHandle            uint16                   // 28-29
+	L3CacheHandle            uint16                   // 30-31 2.3+
+	SerialNumber             string                   // 32 String number
+	AssetTag                 string                   // 33 String number
+	PartNumber               string                   // 34 String number
+	CoreCount                int                      // 35 7.5.6 2.5+
+	CoreEnabled              int                      // 36 7.5.7
+	ThreadCount              int                      // 37 7.5.8
+	ProcessorCharacteristics ProcessorCharacteristics // 38-39  7.5.9
+	ProcessorFamily2         ProcessorFamily          // 40-41 7.5.2 2.6+
+	CoreCount2               int                      // 42-43 7.5.6 3.0+
+	CoreEnabled2             int                      // 44-45 7.5.7
+	ThreadCount2             int                      // 46-47 7.5.8
 }
 
 func ParseProcessorInformation(s *Structure) (*ProcessorInformation, error) {
@@ -49,26 +50,16 @@ func ParseProcessorInformation(s *Structure) (*ProcessorInformation, error) {
 		ret.SocketDesignation = s.Strings[0]
 	}
 
-	t = int(s.Formatted[1])
-	if t > 0 && t <= len(Processor_Type) {
-		ret.ProcessorType = Processor_Type[t-1]
-	}
+	ret.ProcessorType = ProcessorType(s.Formatted[1])
 
-	// TODO: Processor_Family 有一些型号比较模糊，需要具体判断一下
-	t = int(s.Formatted[2])
-	pt, exists := Processor_Family[t]
-	if !exists {
-		ret.ProcessorFamily = Processor_Family[2]
-	} else {
-		ret.ProcessorFamily = pt
-	}
+	// FEh 表示处理器家族见 Processor Family 2，在下面处理
+	ret.ProcessorFamily = ProcessorFamily(s.Formatted[2])
 
 	if len(s.Strings) >= 2 {
 		ret.ProcessorManufacturer = s.Strings[1]
 	}
 
-	pid := s.Formatted[4:12]
-	ret.ProcessorID = fmt.Sprintf("%02X %02X %02X %02X %02X %02X %02X %02X", pid[0], pid[1], pid[2], pid[3], pid[4], pid[5], pid[6], pid[7])
+	copy(ret.ProcessorID[:], s.Formatted[4:12])
 
 	if len(s.Strings) >= 3 {
 		ret.ProcessorVersion = s.Strings[2]
@@ -81,17 +72,12 @@ func ParseProcessorInformation(s *Structure) (*ProcessorInformation, error) {
 		ret.Voltage = "Unknown"
 	}
 
-	ret.ExternalClock = binary.LittleEndian.Uint16(s.Formatted[14:16])
-	ret.MaxSpeed = int(binary.LittleEndian.Uint16(s.Formatted[16:18]))
-	ret.CurrentSpeed = int(binary.LittleEndian.Uint16(s.Formatted[18:20]))
+	ret.ExternalClock = Megahertz(binary.LittleEndian.Uint16(s.Formatted[14:16]))
+	ret.MaxSpeed = Megahertz(binary.LittleEndian.Uint16(s.Formatted[16:18]))
+	ret.CurrentSpeed = Megahertz(binary.LittleEndian.Uint16(s.Formatted[18:20]))
 	ret.Status = uint8(s.Formatted[20])
 
-	t = int(s.Formatted[21])
-	if t > 0 && t <= len(Processor_Upgrade) {
-		ret.ProcessorUpgrade = Processor_Upgrade[t-1]
-	} else {
-		ret.ProcessorUpgrade = Processor_Upgrade[1]
-	}
+	ret.ProcessorUpgrade = ProcessorUpgrade(s.Formatted[21])
 
 	ret.L1CacheHandle = binary.LittleEndian.Uint16(s.Formatted[22:24])
 	ret.L2CacheHandle = binary.LittleEndian.Uint16(s.Formatted[24:26])
@@ -130,19 +116,14 @@ func ParseProcessorInformation(s *Structure) (*ProcessorInformation, error) {
 		ret.ThreadCount = int(binary.LittleEndian.Uint16(s.Formatted[42:44]))
 	}
 
-	t = int(binary.LittleEndian.Uint16(s.Formatted[34:36]))
-	for i := uint(0); i < 8; i++ {
-		if (t>>i)&0x01 == 0x01 {
-			ret.ProcessorCharacteristics = append(ret.ProcessorCharacteristics, Processor_Characteristics[i])
-		}
-	}
+	ret.ProcessorCharacteristics = ProcessorCharacteristics(binary.LittleEndian.Uint16(s.Formatted[34:36]))
 
-	t = int(binary.LittleEndian.Uint16(s.Formatted[36:38]))
-	pf2, exists := Processor_Family[t]
-	if !exists {
-		ret.ProcessorFamily2 = Processor_Family[2]
-	} else {
-		ret.ProcessorFamily2 = pf2
+	// 2.6+
+	if len(s.Formatted) >= 38 {
+		ret.ProcessorFamily2 = ProcessorFamily(binary.LittleEndian.Uint16(s.Formatted[36:38]))
+		if ret.ProcessorFamily == 0xFE {
+			ret.ProcessorFamily = ret.ProcessorFamily2
+		}
 	}
 
 	// 3.0+
@@ -155,6 +136,29 @@ func ParseProcessorInformation(s *Structure) (*ProcessorInformation, error) {
 	return ret, nil
 }
 
+// FamilyName 返回处理器家族的名称。BEh 同时表示 Intel Core 2 和 AMD K7，
+// 按照厂商区分
+func (p *ProcessorInformation) FamilyName() string {
+	if p.ProcessorFamily == 0xBE {
+		m := strings.ToUpper(p.ProcessorManufacturer)
+		switch {
+		case strings.Contains(m, "INTEL"):
+			return "Core 2"
+		case strings.Contains(m, "AMD"):
+			return "K7"
+		}
+	}
+	return p.ProcessorFamily.String()
+}
+
+// SoCID 返回 ARM64 处理器的 SoC ID，只有设置了 Arm64 SoC ID 特性位时才有效
+func (p *ProcessorInformation) SoCID() (SoCID, bool) {
+	if !p.ProcessorCharacteristics.Has(ProcessorCharArm64SoCID) {
+		return SoCID{}, false
+	}
+	return p.ProcessorID.SoCID(), true
+}
+
 var Processor_Type = []string{ /* 7.5.1 */
 	"Other", /* 0x01 */
 	"Unknown",
@@ -319,7 +323,8 @@ var Processor_Family = map[int]string{ /* 7.5.2 */
 	0xBB: "Pentium D",
 	0xBC: "Pentium EE",
 	0xBD: "Core Solo",
-	/* 0x:E handled as a special case */
+	/* 0xBE 同时表示 Core 2 和 K7，由 FamilyName 按厂商区分 */
+	0xBE:  "Core 2 or K7",
 	0xBF:  "Core 2 Duo",
 	0xC0:  "Core 2 Solo",
 	0xC1:  "Core 2 Extreme",
@@ -365,8 +370,10 @@ var Processor_Family = map[int]string{ /* 7.5.2 */
 	0xEF:  "Sempron M",
 	0xFA:  "i860",
 	0xFB:  "i960",
+	0xFE:  "Processor Family 2",
 	0x100: "ARMv7",
 	0x101: "ARMv8",
+	0x102: "ARMv9",
 	0x104: "SH-3",
 	0x105: "SH-4",
 	0x118: "ARM",
@@ -377,6 +384,34 @@ var Processor_Family = map[int]string{ /* 7.5.2 */
 	0x140: "WinChip",
 	0x15E: "DSP",
 	0x1F4: "Video Processor",
+	0x200: "RV32",
+	0x201: "RV64",
+	0x202: "RV128",
+	0x258: "LoongArch",
+	0x259: "Loongson 1",
+	0x25A: "Loongson 2",
+	0x25B: "Loongson 3",
+	0x25C: "Loongson 2K",
+	0x25D: "Loongson 3A",
+	0x25E: "Loongson 3B",
+	0x25F: "Loongson 3C",
+	0x260: "Loongson 3D",
+	0x261: "Loongson 3E",
+	0x262: "Dual-Core Loongson 2K 2xxx",
+	0x26C: "Quad-Core Loongson 3A 5xxx",
+	0x26D: "Multi-Core Loongson 3A 5xxx",
+	0x26E: "Quad-Core Loongson 3B 5xxx",
+	0x26F: "Multi-Core Loongson 3B 5xxx",
+	0x270: "Multi-Core Loongson 3C 5xxx",
+	0x271: "Multi-Core Loongson 3D 5xxx",
+	0x300: "Core 3",
+	0x301: "Core 5",
+	0x302: "Core 7",
+	0x303: "Core 9",
+	0x304: "Core Ultra 3",
+	0x305: "Core Ultra 5",
+	0x306: "Core Ultra 7",
+	0x307: "Core Ultra 9",
 }
 
 var Processor_Voltage = []string{ /* 7.5.4 */
@@ -456,7 +491,34 @@ var Processor_Upgrade = []string{ /* 7.5.5 */
 	"Socket LGA2066",
 	"Socket BGA1392",
 	"Socket BGA1510",
-	"Socket BGA1528", /* 0x3C */
+	"Socket BGA1528",
+	"Socket LGA4189",
+	"Socket LGA1200",
+	"Socket LGA4677",
+	"Socket LGA1700",
+	"Socket BGA1744",
+	"Socket BGA1781",
+	"Socket BGA1211",
+	"Socket BGA2422",
+	"Socket LGA1211",
+	"Socket LGA2422",
+	"Socket LGA5773",
+	"Socket BGA5773",
+	"Socket AM5",
+	"Socket SP5",
+	"Socket SP6",
+	"Socket BGA883",
+	"Socket BGA1190",
+	"Socket BGA4129",
+	"Socket LGA4710",
+	"Socket LGA7529",
+	"Socket BGA1964",
+	"Socket BGA1792",
+	"Socket BGA2049",
+	"Socket BGA2551",
+	"Socket LGA1851",
+	"Socket BGA2114",
+	"Socket BGA2833", /* 0x57 */
 }
 
 var Processor_Characteristics = []string{ /* 7.5.9 */
@@ -467,5 +529,57 @@ var Processor_Characteristics = []string{ /* 7.5.9 */
 	"Hardware Thread",
 	"Execute Protection",
 	"Enhanced Virtualization",
-	"Power/Performance Control", /* bit 7 */
+	"Power/Performance Control",
+	"128-bit Capable",
+	"Arm64 SoC ID", /* bit 9 */
+}
+
+// ProcessorCharacteristics 是处理器特性位（7.5.9）
+type ProcessorCharacteristics uint16
+
+// 处理器特性位
+const (
+	ProcessorCharUnknown ProcessorCharacteristics = 1 << (iota + 1)
+	ProcessorChar64Bit
+	ProcessorCharMultiCore
+	ProcessorCharHardwareThread
+	ProcessorCharExecuteProtection
+	ProcessorCharEnhancedVirtualization
+	ProcessorCharPowerPerformanceControl
+	ProcessorChar128Bit
+	ProcessorCharArm64SoCID // bit 9
+)
+
+var processorCharacteristics = newFlagSet("ProcessorCharacteristics", 16, Processor_Characteristics)
+
+// Has 返回是否设置了 flag 中的所有位
+func (c ProcessorCharacteristics) Has(flag ProcessorCharacteristics) bool { return c&flag == flag }
+
+// Flags 把设置的每一位作为单独的值返回
+func (c ProcessorCharacteristics) Flags() []ProcessorCharacteristics {
+	var out []ProcessorCharacteristics
+	for _, b := range processorCharacteristics.bits(uint64(c)) {
+		out = append(out, ProcessorCharacteristics(b))
+	}
+	return out
+}
+
+// Strings 返回设置的每一位的名称
+func (c ProcessorCharacteristics) Strings() []string {
+	return processorCharacteristics.strings(uint64(c))
+}
+
+// String implements fmt.Stringer.
+func (c ProcessorCharacteristics) String() string { return strings.Join(c.Strings(), ", ") }
+
+// MarshalJSON implements json.Marshaler.
+func (c ProcessorCharacteristics) MarshalJSON() ([]byte, error) {
+	return processorCharacteristics.marshalJSON(uint64(c))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *ProcessorCharacteristics) UnmarshalJSON(b []byte) error {
+	v, err := processorCharacteristics.unmarshalJSON(b)
+	*c = ProcessorCharacteristics(v)
+	return err
 }
@@ -0,0 +1,60 @@
+package smbios_test
+
+import (
+	"testing"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestProcessorFamily(t *testing.T) {
+	tests := []struct {
+		name         string
+		family       byte
+		family2      uint16
+		manufacturer string
+		want         string
+		upgrade      byte
+		socket       string
+	}{
+		{name: "Xeon", family: 0xB3, family2: 0xB3, manufacturer: "Intel(R) Corporation", want: "Xeon", upgrade: 0x3F, socket: "Socket LGA4677"},
+		{name: "Core Ultra", family: 0xFE, family2: 0x306, manufacturer: "Intel(R) Corporation", want: "Core Ultra 7", upgrade: 0x55, socket: "Socket LGA1851"},
+		{name: "EPYC", family: 0x6B, family2: 0x6B, manufacturer: "Advanced Micro Devices, Inc.", want: "Zen", upgrade: 0x4A, socket: "Socket SP5"},
+		{name: "LoongArch", family: 0xFE, family2: 0x26D, manufacturer: "Loongson", want: "Multi-Core Loongson 3A 5xxx", upgrade: 0x01, socket: "Other"},
+		{name: "RISC-V", family: 0xFE, family2: 0x201, want: "RV64", upgrade: 0x06, socket: "None"},
+		{name: "Core 2", family: 0xBE, family2: 0xBE, manufacturer: "GenuineIntel", want: "Core 2", upgrade: 0x15, socket: "Socket LGA775"},
+		{name: "K7", family: 0xBE, family2: 0xBE, manufacturer: "AMD", want: "K7", upgrade: 0x0E, socket: "Socket A (Socket 462)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := make([]byte, 44)
+			f[1] = 0x03
+			f[2] = tt.family
+			f[3] = 0x02
+			f[21] = tt.upgrade
+			f[36], f[37] = byte(tt.family2), byte(tt.family2>>8)
+
+			strs := []string{"CPU0", tt.manufacturer}
+			if tt.manufacturer == "" {
+				f[3] = 0
+				strs = strs[:1]
+			}
+
+			p, err := smbios.ParseProcessorInformation(&smbios.Structure{
+				Header:    smbios.Header{Type: 4, Length: 48},
+				Formatted: f,
+				Strings:   strs,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := p.FamilyName(); got != tt.want {
+				t.Fatalf("unexpected family: want %q, got %q", tt.want, got)
+			}
+			if got := p.ProcessorUpgrade.String(); got != tt.socket {
+				t.Fatalf("unexpected upgrade: want %q, got %q", tt.socket, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ProcessorID 是 type 4 的 Processor ID 字段（7.5.3），保存原始的 8 个字节，
+// 内容取决于处理器架构，用 CPUID、MIDR 或 SoCID 解析
+type ProcessorID [8]byte
+
+// String 返回以空格分隔的十六进制字节，例如 "57 06 05 00 FF FB EB BF"
+func (id ProcessorID) String() string {
+	return fmt.Sprintf("%02X %02X %02X %02X %02X %02X %02X %02X",
+		id[0], id[1], id[2], id[3], id[4], id[5], id[6], id[7])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id ProcessorID) MarshalText() ([]byte, error) { return []byte(id.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *ProcessorID) UnmarshalText(b []byte) error {
+	var out ProcessorID
+	f := strings.Fields(string(b))
+	if len(f) != len(out) {
+		return fmt.Errorf("invalid ProcessorID %q", b)
+	}
+	for i, s := range f {
+		v, err := strconv.ParseUint(s, 16, 8)
+		if err != nil || len(s) != 2 {
+			return fmt.Errorf("invalid ProcessorID %q", b)
+		}
+		out[i] = uint8(v)
+	}
+	*id = out
+	return nil
+}
+
+// CPUID 按 x86 解析：前 4 个字节是 CPUID leaf 1 的 EAX（处理器签名），
+// 后 4 个字节是 EDX（特性标志）
+func (id ProcessorID) CPUID() X86CPUID {
+	eax := binary.LittleEndian.Uint32(id[0:4])
+	ret := X86CPUID{
+		Signature:      eax,
+		Stepping:       uint8(eax & 0xF),
+		BaseModel:      uint8(eax >> 4 & 0xF),
+		BaseFamily:     uint8(eax >> 8 & 0xF),
+		Type:           X86ProcessorType(eax >> 12 & 0x3),
+		ExtendedModel:  uint8(eax >> 16 & 0xF),
+		ExtendedFamily: uint8(eax >> 20 & 0xFF),
+		Features:       X86Features(binary.LittleEndian.Uint32(id[4:8])),
+	}
+
+	ret.Family = uint16(ret.BaseFamily)
+	if ret.BaseFamily == 0xF {
+		ret.Family += uint16(ret.ExtendedFamily)
+	}
+	ret.Model = ret.BaseModel
+	if ret.BaseFamily == 0x6 || ret.BaseFamily == 0xF {
+		ret.Model |= ret.ExtendedModel << 4
+	}
+	return ret
+}
+
+// MIDR 按 ARM 解析：前 4 个字节是 MIDR_EL1 的低 32 位
+func (id ProcessorID) MIDR() MIDR {
+	return MIDR(binary.LittleEndian.Uint32(id[0:4]))
+}
+
+// SoCID 按 ARM64 SoC ID 解析：前 4 个字节是 SMCCC_ARCH_SOC_ID 返回的
+// SoC ID version，后 4 个字节是 SoC ID revision。只有 Processor
+// Characteristics 中设置了 Arm64 SoC ID 位时这种解析才有意义
+func (id ProcessorID) SoCID() SoCID {
+	version := binary.LittleEndian.Uint32(id[0:4])
+	return SoCID{
+		Manufacturer: JEP106{Bank: uint8(version>>24&0x7F) + 1, ID: uint8(version >> 16)},
+		ID:           uint16(version),
+		Revision:     binary.LittleEndian.Uint32(id[4:8]) & 0x7FFFFFFF,
+	}
+}
+
+// X86CPUID 是 x86 处理器的 CPUID 签名和特性标志
+type X86CPUID struct {
+	Signature      uint32 // CPUID leaf 1 EAX
+	Stepping       uint8  // bit 3:0
+	BaseModel      uint8  // bit 7:4
+	BaseFamily     uint8  // bit 11:8
+	Type           X86ProcessorType
+	ExtendedModel  uint8 // bit 19:16
+	ExtendedFamily uint8 // bit 27:20
+	// Family 和 Model 是按照 Intel/AMD 的规则合并扩展字段之后的值
+	Family   uint16
+	Model    uint8
+	Features X86Features // CPUID leaf 1 EDX
+}
+
+// X86ProcessorType 是 CPUID 签名的 bit 13:12
+type X86ProcessorType uint8
+
+var x86ProcessorTypes = newEnum("X86ProcessorType", 0, []string{
+	"Original OEM Processor",
+	"Intel OverDrive Processor",
+	"Dual Processor",
+	"Reserved",
+})
+
+// String implements fmt.Stringer.
+func (t X86ProcessorType) String() string { return x86ProcessorTypes.format(uint16(t)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (t X86ProcessorType) MarshalText() ([]byte, error) { return []byte(t.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *X86ProcessorType) UnmarshalText(b []byte) error {
+	v, err := x86ProcessorTypes.parse(b, 0x3)
+	*t = X86ProcessorType(v)
+	return err
+}
+
+// X86_Features 是 CPUID leaf 1 EDX 的特性标志名称
+var X86_Features = []string{
+	"FPU", /* bit 0 */
+	"VME",
+	"DE",
+	"PSE",
+	"TSC",
+	"MSR",
+	"PAE",
+	"MCE",
+	"CX8",
+	"APIC",
+	"Reserved", /* bit 10 */
+	"SEP",
+	"MTRR",
+	"PGE",
+	"MCA",
+	"CMOV",
+	"PAT",
+	"PSE-36",
+	"PSN",
+	"CLFSH",
+	"Reserved", /* bit 20 */
+	"DS",
+	"ACPI",
+	"MMX",
+	"FXSR",
+	"SSE",
+	"SSE2",
+	"SS",
+	"HTT",
+	"TM",
+	"Reserved", /* bit 30 */
+	"PBE",      /* bit 31 */
+}
+
+// X86Features 是 CPUID leaf 1 EDX 的特性标志
+type X86Features uint32
+
+// 常用的特性标志
+const (
+	X86FeatureFPU  X86Features = 1 << 0
+	X86FeaturePAE  X86Features = 1 << 6
+	X86FeatureAPIC X86Features = 1 << 9
+	X86FeatureMMX  X86Features = 1 << 23
+	X86FeatureSSE  X86Features = 1 << 25
+	X86FeatureSSE2 X86Features = 1 << 26
+	X86FeatureHTT  X86Features = 1 << 28
+)
+
+var x86Features = newFlagSet("X86Features", 32, X86_Features)
+
+// Has 返回是否设置了 flag 中的所有位
+func (f X86Features) Has(flag X86Features) bool { return f&flag == flag }
+
+// Strings 返回设置的每一位的名称
+func (f X86Features) Strings() []string { return x86Features.strings(uint64(f)) }
+
+// String implements fmt.Stringer.
+func (f X86Features) String() string { return strings.Join(f.Strings(), " ") }
+
+// MarshalJSON implements json.Marshaler.
+func (f X86Features) MarshalJSON() ([]byte, error) { return x86Features.marshalJSON(uint64(f)) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *X86Features) UnmarshalJSON(b []byte) error {
+	v, err := x86Features.unmarshalJSON(b)
+	*f = X86Features(v)
+	return err
+}
+
+// MIDR 是 ARM 的 Main ID Register
+type MIDR uint32
+
+// Implementer 返回 bit 31:24 的实现者代码
+func (m MIDR) Implementer() uint8 { return uint8(m >> 24) }
+
+// Variant 返回 bit 23:20
+func (m MIDR) Variant() uint8 { return uint8(m >> 20 & 0xF) }
+
+// Architecture 返回 bit 19:16
+func (m MIDR) Architecture() uint8 { return uint8(m >> 16 & 0xF) }
+
+// PartNum 返回 bit 15:4 的型号
+func (m MIDR) PartNum() uint16 { return uint16(m >> 4 & 0xFFF) }
+
+// Revision 返回 bit 3:0
+func (m MIDR) Revision() uint8 { return uint8(m & 0xF) }
+
+// ImplementerName 返回实现者的名称，未知时返回 ""
+func (m MIDR) ImplementerName() string { return ARM_Implementer[m.Implementer()] }
+
+// String implements fmt.Stringer.
+func (m MIDR) String() string {
+	impl := m.ImplementerName()
+	if impl == "" {
+		impl = fmt.Sprintf("%#02x", m.Implementer())
+	}
+	return fmt.Sprintf("%s part %#03x r%dp%d", impl, m.PartNum(), m.Variant(), m.Revision())
+}
+
+// ARM_Implementer 是 MIDR 中的实现者代码
+var ARM_Implementer = map[uint8]string{
+	0x41: "Arm",
+	0x42: "Broadcom",
+	0x43: "Cavium",
+	0x46: "Fujitsu",
+	0x48: "HiSilicon",
+	0x4E: "NVIDIA",
+	0x50: "Applied Micro",
+	0x51: "Qualcomm",
+	0x61: "Apple",
+	0x69: "Intel",
+	0xC0: "Ampere",
+}
+
+// SoCID 是 ARM64 SMCCC_ARCH_SOC_ID 返回的 SoC 标识
+type SoCID struct {
+	Manufacturer JEP106 // SoC ID version bit 30:16
+	ID           uint16 // SoC ID version bit 15:0
+	Revision     uint32 // SoC ID revision bit 30:0
+}
+
+// String implements fmt.Stringer.
+func (s SoCID) String() string {
+	return fmt.Sprintf("%s SoC %#04x revision %#x", s.Manufacturer, s.ID, s.Revision)
+}
@@ -0,0 +1,94 @@
+package smbios_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestProcessorIDCPUID(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       smbios.ProcessorID
+		family   uint16
+		model    uint8
+		stepping uint8
+	}{
+		{
+			name:     "Intel Cascade Lake",
+			id:       smbios.ProcessorID{0x57, 0x06, 0x05, 0x00, 0xFF, 0xFB, 0xEB, 0xBF},
+			family:   0x06,
+			model:    0x55,
+			stepping: 7,
+		},
+		{
+			name:   "AMD Rome",
+			id:     smbios.ProcessorID{0x10, 0x0F, 0x83, 0x00, 0xFF, 0xFB, 0x8B, 0x17},
+			family: 0x17,
+			model:  0x31,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.id.CPUID()
+			if c.Family != tt.family || c.Model != tt.model || c.Stepping != tt.stepping {
+				t.Fatalf("unexpected signature: family %#x model %#x stepping %d", c.Family, c.Model, c.Stepping)
+			}
+			if c.Type.String() != "Original OEM Processor" {
+				t.Fatalf("unexpected type: %s", c.Type)
+			}
+			if !c.Features.Has(smbios.X86FeatureFPU|smbios.X86FeatureSSE2) || c.Features.Has(1<<10) {
+				t.Fatalf("unexpected features: %s", c.Features)
+			}
+		})
+	}
+}
+
+func TestProcessorIDARM(t *testing.T) {
+	id := smbios.ProcessorID{0xC1, 0xD0, 0x3F, 0x41, 0x00, 0x00, 0x00, 0x00}
+
+	m := id.MIDR()
+	if m.ImplementerName() != "Arm" || m.PartNum() != 0xD0C || m.Variant() != 3 || m.Revision() != 1 {
+		t.Fatalf("unexpected MIDR: %s", m)
+	}
+
+	p := &smbios.ProcessorInformation{
+		ProcessorID:              smbios.ProcessorID{0x01, 0x00, 0x0A, 0x0A, 0x02, 0x00, 0x00, 0x00},
+		ProcessorCharacteristics: smbios.ProcessorChar64Bit,
+	}
+	if _, ok := p.SoCID(); ok {
+		t.Fatal("SoC ID decoded without the Arm64 SoC ID characteristic")
+	}
+
+	p.ProcessorCharacteristics |= smbios.ProcessorCharArm64SoCID
+	s, ok := p.SoCID()
+	if !ok {
+		t.Fatal("SoC ID not decoded")
+	}
+	want := smbios.SoCID{Manufacturer: smbios.JEP106{Bank: 11, ID: 0x0A}, ID: 1, Revision: 2}
+	if s != want {
+		t.Fatalf("unexpected SoC ID: want %+v, got %+v", want, s)
+	}
+}
+
+func TestProcessorIDJSON(t *testing.T) {
+	id := smbios.ProcessorID{0x57, 0x06, 0x05, 0x00, 0xFF, 0xFB, 0xEB, 0xBF}
+
+	b, err := json.Marshal(id)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if want := `"57 06 05 00 FF FB EB BF"`; string(b) != want {
+		t.Fatalf("unexpected JSON: want %s, got %s", want, b)
+	}
+
+	var out smbios.ProcessorID
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if out != id {
+		t.Fatalf("unexpected round trip: %s", out)
+	}
+}
@@ -0,0 +1,145 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MemoryController 内存控制器，2.1 之后被 type 16 取代，只有很老的机器才有
+type MemoryController struct { // 7.6 type 5 obsolete
+	Handle                             uint16
+	ErrorDetectingMethod               string   // 4 7.6.1
+	ErrorCorrectingCapabilities        []string // 5 7.6.2
+	SupportedInterleave                string   // 6 7.6.3
+	CurrentInterleave                  string   // 7 7.6.3
+	MaximumMemoryModuleSize            int      // 8 uint:MB 2^n
+	SupportedSpeeds                    []string // 9-10 7.6.4
+	SupportedMemoryTypes               []string // 11-12 7.7.1
+	MemoryModuleVoltage                []string // 13 7.6.5
+	NumberOfAssociatedMemorySlots      uint8    // 14 x
+	MemoryModuleConfigurationHandles   []uint16 // 15 2x type 6
+	EnabledErrorCorrectingCapabilities []string // 15+2x 7.6.2 2.1+
+	// MemoryModules 由 LinkMemoryModules 根据 handle 填充
+	MemoryModules []*MemoryModule
+}
+
+// ParseMemoryController 解析structure
+func ParseMemoryController(s *Structure) (*MemoryController, error) {
+	if s == nil {
+		return nil, fmt.Errorf("structure s is null")
+	}
+	if s.Header.Type != 5 {
+		return nil, fmt.Errorf("structure s is not type 5, but %d", s.Header.Type)
+	}
+	if len(s.Formatted) < 11 {
+		return nil, fmt.Errorf("structure s is too short for type 5: %d bytes", len(s.Formatted))
+	}
+
+	f := s.Formatted
+	ret := &MemoryController{
+		Handle:                        s.Header.Handle,
+		ErrorCorrectingCapabilities:   bitStrings(uint64(f[1]), Memory_Controller_ECC_Capabilities),
+		MaximumMemoryModuleSize:       1 << uint(f[4]),
+		SupportedSpeeds:               bitStrings(uint64(binary.LittleEndian.Uint16(f[5:7])), Memory_Controller_Speeds),
+		SupportedMemoryTypes:          bitStrings(uint64(binary.LittleEndian.Uint16(f[7:9])), Memory_Module_Types),
+		MemoryModuleVoltage:           bitStrings(uint64(f[9]), Memory_Module_Voltage),
+		NumberOfAssociatedMemorySlots: f[10],
+	}
+
+	t := int(f[0])
+	if t > 0 && t <= len(Memory_Controller_Error_Detecting_Method) {
+		ret.ErrorDetectingMethod = Memory_Controller_Error_Detecting_Method[t-1]
+	} else {
+		ret.ErrorDetectingMethod = Memory_Controller_Error_Detecting_Method[1]
+	}
+	ret.SupportedInterleave = getMemoryControllerInterleave(int(f[2]))
+	ret.CurrentInterleave = getMemoryControllerInterleave(int(f[3]))
+
+	n := int(ret.NumberOfAssociatedMemorySlots)
+	if len(f) < 11+2*n {
+		return nil, fmt.Errorf("structure s is too short for %d memory slots: %d bytes", n, len(f))
+	}
+	for i := 0; i < n; i++ {
+		ret.MemoryModuleConfigurationHandles = append(ret.MemoryModuleConfigurationHandles,
+			binary.LittleEndian.Uint16(f[11+2*i:13+2*i]))
+	}
+	// 2.1+
+	if len(f) > 11+2*n {
+		ret.EnabledErrorCorrectingCapabilities = bitStrings(uint64(f[11+2*n]), Memory_Controller_ECC_Capabilities)
+	}
+
+	return ret, nil
+}
+
+// LinkMemoryModules 根据 handle 把内存控制器和 type 6 内存模块关联起来
+func LinkMemoryModules(controllers []*MemoryController, modules []*MemoryModule) {
+	byHandle := make(map[uint16]*MemoryModule, len(modules))
+	for _, m := range modules {
+		if m != nil {
+			byHandle[m.Handle] = m
+		}
+	}
+
+	for _, c := range controllers {
+		if c == nil {
+			continue
+		}
+		for _, h := range c.MemoryModuleConfigurationHandles {
+			if m, ok := byHandle[h]; ok {
+				c.MemoryModules = append(c.MemoryModules, m)
+			}
+		}
+	}
+}
+
+// getMemoryControllerInterleave 获取交错方式
+func getMemoryControllerInterleave(n int) string {
+	if n > 0 && n <= len(Memory_Controller_Interleave) {
+		return Memory_Controller_Interleave[n-1]
+	}
+	return Memory_Controller_Interleave[1]
+}
+
+var Memory_Controller_Error_Detecting_Method = []string{ /* 7.6.1 */
+	"Other", /* 0x01 */
+	"Unknown",
+	"None",
+	"8-bit Parity",
+	"32-bit ECC",
+	"64-bit ECC",
+	"128-bit ECC",
+	"CRC", /* 0x08 */
+}
+
+var Memory_Controller_ECC_Capabilities = []string{ /* 7.6.2 */
+	"Other",   /* bit 0 */
+	"Unknown", /* bit 1 */
+	"None",
+	"Single-bit Error Correcting",
+	"Double-bit Error Correcting",
+	"Error Scrubbing", /* bit 5 */
+}
+
+var Memory_Controller_Interleave = []string{ /* 7.6.3 */
+	"Other", /* 0x01 */
+	"Unknown",
+	"One-way Interleave",
+	"Two-way Interleave",
+	"Four-way Interleave",
+	"Eight-way Interleave",
+	"Sixteen-way Interleave", /* 0x07 */
+}
+
+var Memory_Controller_Speeds = []string{ /* 7.6.4 */
+	"Other",   /* bit 0 */
+	"Unknown", /* bit 1 */
+	"70 ns",
+	"60 ns",
+	"50 ns", /* bit 4 */
+}
+
+var Memory_Module_Voltage = []string{ /* 7.6.5 */
+	"5.0 V", /* bit 0 */
+	"3.3 V",
+	"2.9 V", /* bit 2 */
+}
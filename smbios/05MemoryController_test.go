@@ -0,0 +1,59 @@
+package smbios_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestParseMemoryController(t *testing.T) {
+	s := &smbios.Structure{
+		Header: smbios.Header{Type: 5, Length: 0x14, Handle: 0x0005},
+		// 64-bit ECC, single-bit correcting, one-way interleave, 1024 MB
+		// modules, 60 ns, DIMM SDRAM, 3.3 V, two slots, single-bit
+		// correcting enabled.
+		Formatted: []byte{
+			0x06, 0x08, 0x03, 0x03, 0x0A,
+			0x08, 0x00,
+			0x00, 0x05,
+			0x02,
+			0x02, 0x08, 0x00, 0x09, 0x00,
+			0x08,
+		},
+	}
+
+	c, err := smbios.ParseMemoryController(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m := &smbios.MemoryModule{Handle: 0x0008}
+	smbios.LinkMemoryModules([]*smbios.MemoryController{c}, []*smbios.MemoryModule{m})
+
+	want := &smbios.MemoryController{
+		Handle:                             0x0005,
+		ErrorDetectingMethod:               "64-bit ECC",
+		ErrorCorrectingCapabilities:        []string{"Single-bit Error Correcting"},
+		SupportedInterleave:                "One-way Interleave",
+		CurrentInterleave:                  "One-way Interleave",
+		MaximumMemoryModuleSize:            1024,
+		SupportedSpeeds:                    []string{"60 ns"},
+		SupportedMemoryTypes:               []string{"DIMM", "SDRAM"},
+		MemoryModuleVoltage:                []string{"3.3 V"},
+		NumberOfAssociatedMemorySlots:      2,
+		MemoryModuleConfigurationHandles:   []uint16{0x0008, 0x0009},
+		EnabledErrorCorrectingCapabilities: []string{"Single-bit Error Correcting"},
+		MemoryModules:                      []*smbios.MemoryModule{m},
+	}
+
+	if diff := cmp.Diff(want, c); diff != "" {
+		t.Fatalf("unexpected memory controller (-want +got):\n%s", diff)
+	}
+
+	// The handle list must fit in the structure.
+	s.Formatted = s.Formatted[:12]
+	if _, err := smbios.ParseMemoryController(s); err == nil {
+		t.Fatal("expected an error for truncated handles, but none occurred")
+	}
+}
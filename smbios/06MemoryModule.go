@@ -0,0 +1,101 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// MemoryModule 内存模块，2.1 之后被 type 17 取代，只有很老的机器才有
+type MemoryModule struct { // 7.7 type 6 obsolete
+	Handle            uint16
+	SocketDesignation string   // 4 String number
+	BankConnections   []uint8  // 5 每个半字节是一个 RAS#，0xF 表示未连接
+	CurrentSpeed      uint8    // 6 uint:ns, 0 表示未知
+	CurrentMemoryType []string // 7-8 7.7.1
+	InstalledSize     int      // 9 7.7.2 uint:MB, 状态见 InstalledSizeStatus
+	// InstalledSizeStatus 为空表示 InstalledSize 有效，否则是
+	// "Not Determinable"、"Disabled"、"Not Installed" 或者 "Out Of Range"
+	InstalledSizeStatus string
+	DoubleBank          bool   // 9 bit 7
+	EnabledSize         int    // 10 7.7.2 uint:MB
+	EnabledSizeStatus   string // 同 InstalledSizeStatus
+	ErrorStatus         string // 11 7.7.3
+}
+
+// ParseMemoryModule 解析structure
+func ParseMemoryModule(s *Structure) (*MemoryModule, error) {
+	if s == nil {
+		return nil, fmt.Errorf("structure s is null")
+	}
+	if s.Header.Type != 6 {
+		return nil, fmt.Errorf("structure s is not type 6, but %d", s.Header.Type)
+	}
+	if len(s.Formatted) < 8 {
+		return nil, fmt.Errorf("structure s is too short for type 6: %d bytes", len(s.Formatted))
+	}
+
+	f := s.Formatted
+	ret := &MemoryModule{
+		Handle:            s.Header.Handle,
+		SocketDesignation: s.StringAt(0),
+		CurrentSpeed:      f[2],
+		CurrentMemoryType: bitStrings(uint64(binary.LittleEndian.Uint16(f[3:5])), Memory_Module_Types),
+		DoubleBank:        f[5]&0x80 != 0,
+	}
+
+	for _, ras := range []uint8{f[1] >> 4, f[1] & 0x0F} {
+		if ras != 0x0F {
+			ret.BankConnections = append(ret.BankConnections, ras)
+		}
+	}
+
+	ret.InstalledSize, ret.InstalledSizeStatus = getMemoryModuleSize(f[5])
+	ret.EnabledSize, ret.EnabledSizeStatus = getMemoryModuleSize(f[6])
+
+	e := f[7]
+	switch {
+	case e&0x04 != 0:
+		ret.ErrorStatus = "See Event Log"
+	case e&0x03 == 0:
+		ret.ErrorStatus = "OK"
+	case e&0x01 != 0:
+		ret.ErrorStatus = "Uncorrectable Errors"
+	default:
+		ret.ErrorStatus = "Correctable Errors"
+	}
+
+	return ret, nil
+}
+
+// getMemoryModuleSize 解析 7.7.2 的大小字段，返回 MB 和状态
+func getMemoryModuleSize(b uint8) (int, string) {
+	n := b & 0x7F
+	switch n {
+	case 0x7D:
+		return 0, "Not Determinable"
+	case 0x7E:
+		return 0, "Disabled"
+	case 0x7F:
+		return 0, "Not Installed"
+	}
+	// 2^n MB，超出 int 范围的值没有意义
+	if int(n) >= bits.UintSize-1 {
+		return 0, "Out Of Range"
+	}
+	return 1 << uint(n), ""
+}
+
+var Memory_Module_Types = []string{ /* 7.7.1 */
+	"Other",   /* bit 0 */
+	"Unknown", /* bit 1 */
+	"Standard",
+	"FPM",
+	"EDO",
+	"Parity",
+	"ECC",
+	"SIMM",
+	"DIMM",
+	"Burst EDO",
+	"SDRAM", /* bit 10 */
+}
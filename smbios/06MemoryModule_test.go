@@ -0,0 +1,60 @@
+package smbios_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestParseMemoryModule(t *testing.T) {
+	s := &smbios.Structure{
+		Header: smbios.Header{Type: 6, Length: 0x0C, Handle: 0x0008},
+		// Socket 1, RAS 0 and 1, 60 ns, DIMM SDRAM, 64 MB double bank,
+		// disabled, correctable errors.
+		Formatted: []byte{0x01, 0x01, 0x3C, 0x00, 0x05, 0x86, 0x7E, 0x02},
+		Strings:   []string{"DIMM_A1"},
+	}
+
+	want := &smbios.MemoryModule{
+		Handle:            0x0008,
+		SocketDesignation: "DIMM_A1",
+		BankConnections:   []uint8{0, 1},
+		CurrentSpeed:      60,
+		CurrentMemoryType: []string{"DIMM", "SDRAM"},
+		InstalledSize:     64,
+		DoubleBank:        true,
+		EnabledSizeStatus: "Disabled",
+		ErrorStatus:       "Correctable Errors",
+	}
+
+	got, err := smbios.ParseMemoryModule(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected memory module (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseMemoryModuleSizeOutOfRange(t *testing.T) {
+	s := &smbios.Structure{
+		Header: smbios.Header{Type: 6, Length: 0x0C, Handle: 0x0009},
+		// 2^64 MB installed, 2^16 MB enabled.
+		Formatted: []byte{0x01, 0xFF, 0x00, 0x00, 0x01, 0x40, 0x10, 0x00},
+		Strings:   []string{"DIMM_A2"},
+	}
+
+	got, err := smbios.ParseMemoryModule(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.InstalledSize != 0 || got.InstalledSizeStatus != "Out Of Range" {
+		t.Fatalf("unexpected installed size: %d %q", got.InstalledSize, got.InstalledSizeStatus)
+	}
+	if got.EnabledSize != 65536 || got.EnabledSizeStatus != "" {
+		t.Fatalf("unexpected enabled size: %d %q", got.EnabledSize, got.EnabledSizeStatus)
+	}
+}
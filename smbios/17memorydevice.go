@@ -8,39 +8,51 @@ import (
 
 // A MemoryDeviceStructure is an SMBIOS structure.
 type MemoryDeviceStructure struct {
-	PhysicalMemoryArrayHandle               uint16
-	MemoryErrorInformationHandle            uint16
-	TotalWidth                              uint16 /* 2.1+ */
-	DataWidth                               uint16
-	Size                                    int
-	FormFactor                              string
+	Handle                       uint16
+	PhysicalMemoryArrayHandle    uint16
+	MemoryErrorInformationHandle uint16
+	TotalWidth                   uint16 /* 2.1+ */
+	DataWidth                    uint16
+	// Size is 0 when no memory is installed in the socket, or UnknownBytes.
+	Size                                    Bytes
+	FormFactor                              MemoryFormFactor
 	DeviceSet                               string
 	DeviceLocator                           string
 	BankLocator                             string
-	MemoryType                              string
+	MemoryType                              MemoryType
 	TypeDetail                              string
-	Speed                                   uint16 /* 2.3+ */
+	Speed                                   Megatransfers /* 2.3+ */
 	Manufacturer                            string
 	SerialNumber                            string
 	AssetTag                                string
 	PartNumber                              string
-	Attributes                              uint8  /* 2.6+ */
-	ExtendedSize                            uint32 /* 2.7+ */
-	ConfiguredMemoryClockSpeed              uint16
-	MinimumVoltage                          uint16 /* 2.8+ */
-	MaximumVoltage                          uint16
-	ConfiguredVoltage                       uint16
-	MemoryTechnology                        string /* 3.2+ */
+	Attributes                              uint8 /* 2.6+ */
+	ExtendedSize                            Bytes /* 2.7+ */
+	ConfiguredMemoryClockSpeed              Megatransfers
+	MinimumVoltage                          Millivolts /* 2.8+ */
+	MaximumVoltage                          Millivolts
+	ConfiguredVoltage                       Millivolts
+	MemoryTechnology                        MemoryTechnology /* 3.2+ */
 	MemoryOperatingModeCapability           string
 	FirmwareVersion                         string
 	ModuleManufacturerID                    uint16
 	ModuleProductID                         uint16
 	MemorySubsystemControllerManufacturerID uint16
 	MemorySubsystemControllerProductID      uint16
-	NonVolatileSize                         uint32
-	VolatileSize                            uint32
-	CacheSize                               uint32
-	LogicalSize                             uint32
+	// The following sizes are 0 when the portion is absent, or UnknownBytes.
+	NonVolatileSize Bytes
+	VolatileSize    Bytes
+	CacheSize       Bytes
+	LogicalSize     Bytes
+	// ExtendedSpeed 和 ExtendedConfiguredMemorySpeed 在 Speed 和
+	// ConfiguredMemoryClockSpeed 为 FFFFh 时有效，解析时已经合并到这两个字段中
+	ExtendedSpeed                 Megatransfers /* 3.3+ */
+	ExtendedConfiguredMemorySpeed Megatransfers
+	// PMIC0 和 RCD 的厂商 ID 是 JEDEC JEP-106 编码，0 表示未知
+	PMIC0ManufacturerID uint16 /* 3.7+ */
+	PMIC0RevisionNumber uint16
+	RCDManufacturerID   uint16
+	RCDRevisionNumber   uint16
 }
 
 // Memory_Device_Factor 设备接口类型？
@@ -60,15 +72,9 @@ var Memory_Device_Factor = []string{ /* 7.18.1 */
 	"RIMM",
 	"SODIMM",
 	"SRIMM",
-	"FB-DIMM", /* 0x0F */
-}
-
-// getMemoryDeviceFactor 获取内存接口类型
-func getMemoryDeviceFactor(n int) string {
-	if n < 0 || n > len(Memory_Device_Factor) {
-		return Memory_Device_Factor[0]
-	}
-	return Memory_Device_Factor[n]
+	"FB-DIMM",
+	"Die",
+	"CAMM", /* 0x11 */
 }
 
 // Memory_Device_Type 内存类型
@@ -104,15 +110,12 @@ var Memory_Device_Type = []string{ /* 7.18.2 */
 	"LPDDR2",
 	"LPDDR3",
 	"LPDDR4",
-	"Logical non-volatile device", /* 0x1F */
-}
-
-// getMemoryDeviceType 获取内存类型
-func getMemoryDeviceType(n int) string {
-	if n < 0 || n > len(Memory_Device_Type) {
-		return Memory_Device_Type[0]
-	}
-	return Memory_Device_Type[n]
+	"Logical non-volatile device",
+	"HBM",
+	"HBM2",
+	"DDR5",
+	"LPDDR5",
+	"HBM3", /* 0x24 */
 }
 
 // Memory_Device_Detail 内存信息明细
@@ -172,40 +175,24 @@ func ParseMemoryDevice(s *Structure) (*MemoryDeviceStructure, error) {
 	if s.Header.Type != 17 {
 		return nil, fmt.Errorf("parameter is not a memory device")
 	}
-
-	ret := &MemoryDeviceStructure{}                                                 // 0-3
-	ret.PhysicalMemoryArrayHandle = binary.LittleEndian.Uint16(s.Formatted[:2])     // 0-1
-	ret.MemoryErrorInformationHandle = binary.LittleEndian.Uint16(s.Formatted[2:4]) // 2-3
-	ret.TotalWidth = binary.LittleEndian.Uint16(s.Formatted[4:6])                   // 4-5
-	ret.DataWidth = binary.LittleEndian.Uint16(s.Formatted[6:8])                    // 6-7
-
-	// Only parse the DIMM size.
-	dimmSize := int(binary.LittleEndian.Uint16(s.Formatted[8:10]))
-	//If the DIMM size is 32GB or greater, we need to parse the extended field.
-	// Spec says 0x7fff in regular size field means we should parse the extended.
-	if dimmSize == 0x7fff {
-		dimmSize = int(binary.LittleEndian.Uint32(s.Formatted[24:28]))
+	if len(s.Formatted) < 17 {
+		return nil, fmt.Errorf("structure s is too short for type 17: %d bytes", len(s.Formatted))
 	}
 
-	// The granularity in which the value is specified
-	// depends on the setting of the most-significant bit (bit
-	// 15). If the bit is 0, the value is specified in megabyte
-	// units; if the bit is 1, the value is specified in kilobyte
-	// units.
-	//
-	// Little endian MSB for uint16 is in second byte.
+	f := s.Formatted
+	ret := &MemoryDeviceStructure{Handle: s.Header.Handle}                // 0-3
+	ret.PhysicalMemoryArrayHandle = binary.LittleEndian.Uint16(f[:2])     // 0-1
+	ret.MemoryErrorInformationHandle = binary.LittleEndian.Uint16(f[2:4]) // 2-3
+	ret.TotalWidth = binary.LittleEndian.Uint16(f[4:6])                   // 4-5
+	ret.DataWidth = binary.LittleEndian.Uint16(f[6:8])                    // 6-7
 
-	if s.Formatted[9]&0x80 == 0 {
-		dimmSize = dimmSize * 1024
+	// 2.7+ 32GB 及以上的容量记录在 Extended Size 中
+	if len(f) >= 28 {
+		ret.ExtendedSize = getMemoryDeviceExtendedSize(binary.LittleEndian.Uint32(f[24:28])) // 24-27
 	}
-	ret.Size = dimmSize // 8-9
+	ret.Size = getMemoryDeviceSize(binary.LittleEndian.Uint16(f[8:10]), ret.ExtendedSize) // 8-9
 
-	formFactor := int(s.Formatted[10]) //10
-	if formFactor < 0 || formFactor > len(Memory_Device_Factor) {
-		ret.FormFactor = Memory_Device_Factor[0]
-	} else {
-		ret.FormFactor = Memory_Device_Factor[formFactor]
-	}
+	ret.FormFactor = MemoryFormFactor(f[10]) // 10
 
 	if len(s.Strings) >= 1 {
 		ret.DeviceSet = strings.TrimSpace(s.Strings[0]) // 11 String number
@@ -217,56 +204,45 @@ func ParseMemoryDevice(s *Structure) (*MemoryDeviceStructure, error) {
 		ret.BankLocator = strings.TrimSpace(s.Strings[2]) // 13 String number
 	}
 
-	memoryType := int(s.Formatted[14]) // 14
-	if memoryType > 0 || memoryType > len(Memory_Device_Type) {
-		ret.MemoryType = Memory_Device_Type[0]
-	} else {
-		ret.MemoryType = Memory_Device_Type[memoryType]
-	}
-
-	ret.TypeDetail = Memory_Device_Detail[int(binary.LittleEndian.Uint16(s.Formatted[15:17]))] // 15-16
-	ret.Speed = binary.LittleEndian.Uint16(s.Formatted[17:19])                                 // 17-18
-	if len(s.Strings) >= 4 {
-		ret.Manufacturer = strings.TrimSpace(s.Strings[3]) // 19 String number  2.3+
-
-	}
-	if len(s.Strings) >= 5 {
-		ret.SerialNumber = strings.TrimSpace(s.Strings[4]) // 20 String number
-	}
-	if len(s.Strings) >= 6 {
-		ret.AssetTag = strings.TrimSpace(s.Strings[5]) // 21 String number
-	}
-	if len(s.Strings) >= 7 {
-		ret.PartNumber = strings.TrimSpace(s.Strings[6]) // 22 String number
+	ret.MemoryType = MemoryType(f[14]) // 14
 
+	ret.TypeDetail = Memory_Device_Detail[int(binary.LittleEndian.Uint16(f[15:17]))] // 15-16
+	// 2.3+
+	if len(f) >= 23 {
+		ret.Speed = Megatransfers(binary.LittleEndian.Uint16(f[17:19])) // 17-18
+		if len(s.Strings) >= 4 {
+			ret.Manufacturer = strings.TrimSpace(s.Strings[3]) // 19 String number
+		}
+		if len(s.Strings) >= 5 {
+			ret.SerialNumber = strings.TrimSpace(s.Strings[4]) // 20 String number
+		}
+		if len(s.Strings) >= 6 {
+			ret.AssetTag = strings.TrimSpace(s.Strings[5]) // 21 String number
+		}
+		if len(s.Strings) >= 7 {
+			ret.PartNumber = strings.TrimSpace(s.Strings[6]) // 22 String number
+		}
 	}
-	// 2.6+ length > 27
-	if s.Header.Length > 27 {
-		ret.Attributes = uint8(s.Formatted[23]) // 23 2.6+
+	// 2.6+
+	if len(f) >= 24 {
+		ret.Attributes = f[23] // 23
 	}
-	// 2.7+ length > 28
-	if s.Header.Length > 28 {
-		ret.ExtendedSize = binary.LittleEndian.Uint32(s.Formatted[24:28])               // 24-27 2.7+
-		ret.ConfiguredMemoryClockSpeed = binary.LittleEndian.Uint16(s.Formatted[28:30]) //28-29
+	// 2.7+
+	if len(f) >= 30 {
+		ret.ConfiguredMemoryClockSpeed = Megatransfers(binary.LittleEndian.Uint16(f[28:30])) // 28-29
 	}
-	// 2.8+ length >34
-	if s.Header.Length > 34 {
-		ret.MinimumVoltage = binary.LittleEndian.Uint16(s.Formatted[30:32])    // 30-31 2.8+
-		ret.MaximumVoltage = binary.LittleEndian.Uint16(s.Formatted[32:34])    // 32-33
-		ret.ConfiguredVoltage = binary.LittleEndian.Uint16(s.Formatted[34:36]) // 34-35
+	// 2.8+
+	if len(f) >= 36 {
+		ret.MinimumVoltage = Millivolts(binary.LittleEndian.Uint16(f[30:32]))    // 30-31
+		ret.MaximumVoltage = Millivolts(binary.LittleEndian.Uint16(f[32:34]))    // 32-33
+		ret.ConfiguredVoltage = Millivolts(binary.LittleEndian.Uint16(f[34:36])) // 34-35
 	}
-	// 3.2+ length >40
-	if s.Header.Length > 40 {
-
-		memoryTechnology := int(s.Formatted[36]) // 36 3.2+
-		if memoryTechnology < 0 || memoryTechnology > len(Memory_Device_Technology) {
-			ret.MemoryTechnology = Memory_Device_Technology[0]
-		} else {
-			ret.MemoryTechnology = Memory_Device_Technology[memoryTechnology]
-		}
+	// 3.2+
+	if len(f) >= 80 {
+		ret.MemoryTechnology = MemoryTechnology(f[36]) // 36
 
-		MemoryOperatingModeCapability := int(binary.LittleEndian.Uint16(s.Formatted[37:39])) // 37-38
-		if MemoryOperatingModeCapability < 0 || MemoryOperatingModeCapability > len(Memory_Device_Operating_Mode_Capability) {
+		MemoryOperatingModeCapability := int(binary.LittleEndian.Uint16(f[37:39])) // 37-38
+		if MemoryOperatingModeCapability < 0 || MemoryOperatingModeCapability >= len(Memory_Device_Operating_Mode_Capability) {
 			ret.MemoryOperatingModeCapability = Memory_Device_Operating_Mode_Capability[0]
 		} else {
 			ret.MemoryOperatingModeCapability = Memory_Device_Operating_Mode_Capability[MemoryOperatingModeCapability]
@@ -274,15 +250,53 @@ func ParseMemoryDevice(s *Structure) (*MemoryDeviceStructure, error) {
 		if len(s.Strings) >= 8 {
 			ret.FirmwareVersion = strings.TrimSpace(s.Strings[7]) // 39 String number
 		}
-		ret.ModuleManufacturerID = binary.LittleEndian.Uint16(s.Formatted[40:42])                    // 40-41
-		ret.ModuleProductID = binary.LittleEndian.Uint16(s.Formatted[42:44])                         // 42-43
-		ret.MemorySubsystemControllerManufacturerID = binary.LittleEndian.Uint16(s.Formatted[44:46]) // 44-45
-		ret.MemorySubsystemControllerProductID = binary.LittleEndian.Uint16(s.Formatted[46:48])      //46-47
-		ret.NonVolatileSize = binary.LittleEndian.Uint32(s.Formatted[48:52])                         // 48-51
-		ret.VolatileSize = binary.LittleEndian.Uint32(s.Formatted[52:56])                            // 52-55
-		ret.CacheSize = binary.LittleEndian.Uint32(s.Formatted[56:60])                               // 56-59
-		ret.LogicalSize = binary.LittleEndian.Uint32(s.Formatted[60:64])                             // 60-63
+		ret.ModuleManufacturerID = binary.LittleEndian.Uint16(f[40:42])                    // 40-41
+		ret.ModuleProductID = binary.LittleEndian.Uint16(f[42:44])                         // 42-43
+		ret.MemorySubsystemControllerManufacturerID = binary.LittleEndian.Uint16(f[44:46]) // 44-45
+		ret.MemorySubsystemControllerProductID = binary.LittleEndian.Uint16(f[46:48])      // 46-47
+		ret.NonVolatileSize = Bytes(binary.LittleEndian.Uint64(f[48:56]))                  // 48-55
+		ret.VolatileSize = Bytes(binary.LittleEndian.Uint64(f[56:64]))                     // 56-63
+		ret.CacheSize = Bytes(binary.LittleEndian.Uint64(f[64:72]))                        // 64-71
+		ret.LogicalSize = Bytes(binary.LittleEndian.Uint64(f[72:80]))                      // 72-79
+	}
+	// 3.3+ 速度超过 65534 MT/s 时记录在 Extended Speed 中，bit 31 保留
+	if len(f) >= 88 {
+		ret.ExtendedSpeed = Megatransfers(binary.LittleEndian.Uint32(f[80:84]) & 0x7FFFFFFF)                 // 80-83
+		ret.ExtendedConfiguredMemorySpeed = Megatransfers(binary.LittleEndian.Uint32(f[84:88]) & 0x7FFFFFFF) // 84-87
+		if ret.Speed == 0xFFFF {
+			ret.Speed = ret.ExtendedSpeed
+		}
+		if ret.ConfiguredMemoryClockSpeed == 0xFFFF {
+			ret.ConfiguredMemoryClockSpeed = ret.ExtendedConfiguredMemorySpeed
+		}
+	}
+	// 3.7+
+	if len(f) >= 96 {
+		ret.PMIC0ManufacturerID = binary.LittleEndian.Uint16(f[88:90]) // 88-89
+		ret.PMIC0RevisionNumber = binary.LittleEndian.Uint16(f[90:92]) // 90-91
+		ret.RCDManufacturerID = binary.LittleEndian.Uint16(f[92:94])   // 92-93
+		ret.RCDRevisionNumber = binary.LittleEndian.Uint16(f[94:96])   // 94-95
 	}
 
 	return ret, nil
 }
+
+// getMemoryDeviceSize 解析 Size 字段：0 表示没有安装，FFFFh 表示未知，
+// 7FFFh 表示容量见 Extended Size；否则 bit 15 为 1 时单位是 KB，为 0 时单位是 MB
+func getMemoryDeviceSize(n uint16, extended Bytes) Bytes {
+	switch {
+	case n == 0xFFFF:
+		return UnknownBytes
+	case n == 0x7FFF:
+		return extended
+	case n&0x8000 != 0:
+		return Bytes(n&0x7FFF) * KiB
+	default:
+		return Bytes(n) * MiB
+	}
+}
+
+// getMemoryDeviceExtendedSize 解析 Extended Size 字段，bit 31 保留，bit 30:0 单位是 MB
+func getMemoryDeviceExtendedSize(n uint32) Bytes {
+	return Bytes(n&0x7FFFFFFF) * MiB
+}
@@ -0,0 +1,52 @@
+package smbios_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestParseMemoryDeviceDDR5(t *testing.T) {
+	f := make([]byte, 96)
+	binary.LittleEndian.PutUint16(f[8:10], 0x7FFF)
+	binary.LittleEndian.PutUint32(f[24:28], 0x00010000)
+	f[10] = 0x09 // DIMM
+	f[14] = 0x22 // DDR5
+	binary.LittleEndian.PutUint16(f[17:19], 0xFFFF)
+	binary.LittleEndian.PutUint16(f[28:30], 0x1900)
+	f[36] = 0x03 // DRAM
+	binary.LittleEndian.PutUint32(f[80:84], 70400)
+	binary.LittleEndian.PutUint32(f[84:88], 0x80000000|6400)
+	binary.LittleEndian.PutUint16(f[88:90], 0x8A08)
+	binary.LittleEndian.PutUint16(f[90:92], 0x0011)
+	binary.LittleEndian.PutUint16(f[92:94], 0xB304)
+	binary.LittleEndian.PutUint16(f[94:96], 0x0021)
+
+	d, err := smbios.ParseMemoryDevice(&smbios.Structure{
+		Header:    smbios.Header{Type: 17, Length: 100, Handle: 0x1100},
+		Formatted: f,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if d.Size != 64*smbios.GiB {
+		t.Fatalf("unexpected size: %s", d.Size)
+	}
+	if d.MemoryType.String() != "DDR5" || d.FormFactor.String() != "DIMM" || d.MemoryTechnology.String() != "DRAM" {
+		t.Fatalf("unexpected type: %s %s %s", d.MemoryType, d.FormFactor, d.MemoryTechnology)
+	}
+	if d.Speed != 70400 || d.ExtendedSpeed != 70400 {
+		t.Fatalf("unexpected speed: %s (extended %s)", d.Speed, d.ExtendedSpeed)
+	}
+	// Configured speed fits in the 16-bit field, the extended value is only
+	// used when the field holds FFFFh.
+	if d.ConfiguredMemoryClockSpeed != 6400 || d.ExtendedConfiguredMemorySpeed != 6400 {
+		t.Fatalf("unexpected configured speed: %s (extended %s)", d.ConfiguredMemoryClockSpeed, d.ExtendedConfiguredMemorySpeed)
+	}
+	if d.PMIC0ManufacturerID != 0x8A08 || d.PMIC0RevisionNumber != 0x11 || d.RCDManufacturerID != 0xB304 || d.RCDRevisionNumber != 0x21 {
+		t.Fatalf("unexpected PMIC/RCD: %#04x %#04x %#04x %#04x",
+			d.PMIC0ManufacturerID, d.PMIC0RevisionNumber, d.RCDManufacturerID, d.RCDRevisionNumber)
+	}
+}
@@ -0,0 +1,32 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// OutOfBandRemoteAccess 带外远程访问，例如 modem 或者网卡的远程唤醒
+type OutOfBandRemoteAccess struct { // 7.31 type 30
+	ManufacturerName          string // 4 String number
+	InboundConnectionEnabled  bool   // 5 bit 0
+	OutboundConnectionEnabled bool   // 5 bit 1
+}
+
+// ParseOutOfBandRemoteAccess 解析structure
+func ParseOutOfBandRemoteAccess(s *Structure) (*OutOfBandRemoteAccess, error) {
+	if s == nil {
+		return nil, fmt.Errorf("structure s is null")
+	}
+	if s.Header.Type != 30 {
+		return nil, fmt.Errorf("structure s is not type 30, but %d", s.Header.Type)
+	}
+	if len(s.Formatted) < 2 {
+		return nil, fmt.Errorf("structure s is too short for type 30: %d bytes", len(s.Formatted))
+	}
+
+	c := s.Formatted[1]
+	return &OutOfBandRemoteAccess{
+		ManufacturerName:          s.StringAt(0),
+		InboundConnectionEnabled:  c&0x01 != 0,
+		OutboundConnectionEnabled: c&0x02 != 0,
+	}, nil
+}
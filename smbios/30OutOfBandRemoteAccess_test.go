@@ -0,0 +1,76 @@
+package smbios_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestParseOutOfBandRemoteAccess(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		want *smbios.OutOfBandRemoteAccess
+		ok   bool
+	}{
+		{
+			name: "inbound only",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 30, Length: 0x06, Handle: 0x0030},
+				Formatted: []byte{0x01, 0x01},
+				Strings:   []string{"Intel"},
+			},
+			want: &smbios.OutOfBandRemoteAccess{
+				ManufacturerName:         "Intel",
+				InboundConnectionEnabled: true,
+			},
+			ok: true,
+		},
+		{
+			name: "both directions",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 30, Length: 0x06, Handle: 0x0030},
+				Formatted: []byte{0x00, 0x03},
+			},
+			want: &smbios.OutOfBandRemoteAccess{
+				InboundConnectionEnabled:  true,
+				OutboundConnectionEnabled: true,
+			},
+			ok: true,
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 30, Length: 0x05, Handle: 0x0030},
+				Formatted: []byte{0x01},
+			},
+		},
+		{
+			name: "wrong type",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 31, Length: 0x06, Handle: 0x0030},
+				Formatted: []byte{0x01, 0x01},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := smbios.ParseOutOfBandRemoteAccess(tt.s)
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok {
+				if err == nil {
+					t.Fatal("expected an error, but none occurred")
+				}
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected remote access (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
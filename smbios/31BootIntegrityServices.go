@@ -0,0 +1,41 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// BootIntegrityServices BIS 入口点，只保留入口地址和校验结果
+type BootIntegrityServices struct { // 7.32 type 31
+	Checksum        uint8  // 4
+	ChecksumValid   bool   // 整个 structure 的字节和为 0
+	EntryPoint16Bit uint32 // 8-11 segment:offset
+	EntryPoint32Bit uint32 // 12-15 物理地址
+}
+
+// ParseBootIntegrityServices 解析structure
+func ParseBootIntegrityServices(s *Structure) (*BootIntegrityServices, error) {
+	if s == nil {
+		return nil, fmt.Errorf("structure s is null")
+	}
+	if s.Header.Type != 31 {
+		return nil, fmt.Errorf("structure s is not type 31, but %d", s.Header.Type)
+	}
+	// 规范定义的长度为 0x1C，即 24 字节的 formatted 区域
+	if len(s.Formatted) < 24 {
+		return nil, fmt.Errorf("structure s is too short for type 31: %d bytes", len(s.Formatted))
+	}
+
+	// 校验和覆盖 header 和 formatted 区域
+	sum := s.Header.Type + s.Header.Length + uint8(s.Header.Handle) + uint8(s.Header.Handle>>8)
+	for _, b := range s.Formatted {
+		sum += b
+	}
+
+	return &BootIntegrityServices{
+		Checksum:        s.Formatted[0],
+		ChecksumValid:   sum == 0,
+		EntryPoint16Bit: binary.LittleEndian.Uint32(s.Formatted[4:8]),
+		EntryPoint32Bit: binary.LittleEndian.Uint32(s.Formatted[8:12]),
+	}, nil
+}
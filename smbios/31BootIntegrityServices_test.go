@@ -0,0 +1,91 @@
+package smbios_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestParseBootIntegrityServices(t *testing.T) {
+	bis := func(fix func(f []byte)) *smbios.Structure {
+		s := &smbios.Structure{
+			Header: smbios.Header{Type: 31, Length: 0x1C, Handle: 0x0032},
+			Formatted: []byte{
+				0x00,       // checksum
+				0x00,       // reserved
+				0x00, 0x00, // reserved
+				0x34, 0x12, 0x00, 0xF0, // BIS entry point, 16-bit
+				0x00, 0x50, 0x0E, 0x00, // BIS entry point, 32-bit
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+			},
+		}
+
+		sum := s.Header.Type + s.Header.Length + uint8(s.Header.Handle) + uint8(s.Header.Handle>>8)
+		for _, b := range s.Formatted {
+			sum += b
+		}
+		s.Formatted[0] = -sum
+
+		if fix != nil {
+			fix(s.Formatted)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		want *smbios.BootIntegrityServices
+		ok   bool
+	}{
+		{
+			name: "OK",
+			s:    bis(nil),
+			want: &smbios.BootIntegrityServices{
+				Checksum:        0xFF,
+				ChecksumValid:   true,
+				EntryPoint16Bit: 0xF0001234,
+				EntryPoint32Bit: 0x000E5000,
+			},
+			ok: true,
+		},
+		{
+			name: "bad checksum",
+			s:    bis(func(f []byte) { f[0]++ }),
+			want: &smbios.BootIntegrityServices{
+				Checksum:        0x00,
+				EntryPoint16Bit: 0xF0001234,
+				EntryPoint32Bit: 0x000E5000,
+			},
+			ok: true,
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 31, Length: 0x13, Handle: 0x0031},
+				Formatted: make([]byte, 15),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := smbios.ParseBootIntegrityServices(tt.s)
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok {
+				if err == nil {
+					t.Fatal("expected an error, but none occurred")
+				}
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected boot integrity services (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
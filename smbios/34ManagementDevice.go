@@ -0,0 +1,163 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ManagementDevice 管理设备，一般是主板上的传感器芯片
+type ManagementDevice struct { // 7.35 type 34
+	Handle      uint16
+	Description string // 4 String number
+	Type        string // 5 7.35.1
+	Address     uint32 // 6-9
+	AddressType string // 10 7.35.2
+	// Components 引用该设备的 type 35 组件，由 LinkManagementDevices 填充
+	Components []*ManagementDeviceComponent
+}
+
+// ParseManagementDevice 解析structure
+func ParseManagementDevice(s *Structure) (*ManagementDevice, error) {
+	if s == nil {
+		return nil, fmt.Errorf("structure s is null")
+	}
+	if s.Header.Type != 34 {
+		return nil, fmt.Errorf("structure s is not type 34, but %d", s.Header.Type)
+	}
+	if len(s.Formatted) < 7 {
+		return nil, fmt.Errorf("structure s is too short for type 34: %d bytes", len(s.Formatted))
+	}
+
+	ret := &ManagementDevice{
+		Handle:      s.Header.Handle,
+		Description: s.StringAt(0),
+		Address:     binary.LittleEndian.Uint32(s.Formatted[2:6]),
+	}
+
+	t := int(s.Formatted[1])
+	if t > 0 && t <= len(Management_Device_Type) {
+		ret.Type = Management_Device_Type[t-1]
+	} else {
+		ret.Type = Management_Device_Type[1]
+	}
+
+	t = int(s.Formatted[6])
+	if t > 0 && t <= len(Management_Device_Address_Type) {
+		ret.AddressType = Management_Device_Address_Type[t-1]
+	} else {
+		ret.AddressType = Management_Device_Address_Type[1]
+	}
+
+	return ret, nil
+}
+
+// ParseManagementDevices 解析所有 type 34/35/36 structure，并用
+// LinkManagementDevices 按照 handle 组装成 管理设备 -> 组件 -> 探针 + 阈值
+// 的树形结构。
+//
+// 无法解析的 structure 和引用了未知管理设备的组件会被跳过，原因通过 errs 返回，
+// 不影响其他设备。
+func ParseManagementDevices(ss []*Structure) (devices []*ManagementDevice, errs []error) {
+	var (
+		components []*ManagementDeviceComponent
+		thresholds []*ManagementDeviceThresholdData
+	)
+
+	for _, s := range ss {
+		switch s.Header.Type {
+		case 34:
+			d, err := ParseManagementDevice(s)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			devices = append(devices, d)
+		case 35:
+			c, err := ParseManagementDeviceComponent(s)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			components = append(components, c)
+		case 36:
+			t, err := ParseManagementDeviceThresholdData(s)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			thresholds = append(thresholds, t)
+		}
+	}
+
+	errs = append(errs, LinkManagementDevices(devices, components, thresholds, ss)...)
+	return devices, errs
+}
+
+// LinkManagementDevices 根据 handle 把组件挂到 devices 中对应的管理设备上，
+// 并填充组件的探针（ss 中 type 26/27/28/29 的原始 Structure）和阈值。
+// 引用了未知管理设备的组件会被跳过，原因通过 errs 返回。
+func LinkManagementDevices(devices []*ManagementDevice, components []*ManagementDeviceComponent,
+	thresholds []*ManagementDeviceThresholdData, ss []*Structure) (errs []error) {
+	byHandle := make(map[uint16]*Structure, len(ss))
+	for _, s := range ss {
+		byHandle[s.Header.Handle] = s
+	}
+
+	thresholdByHandle := make(map[uint16]*ManagementDeviceThresholdData, len(thresholds))
+	for _, t := range thresholds {
+		if t != nil {
+			thresholdByHandle[t.Handle] = t
+		}
+	}
+
+	deviceByHandle := make(map[uint16]*ManagementDevice, len(devices))
+	for _, d := range devices {
+		if d != nil {
+			deviceByHandle[d.Handle] = d
+		}
+	}
+
+	for _, c := range components {
+		if c == nil {
+			continue
+		}
+		d, ok := deviceByHandle[c.ManagementDeviceHandle]
+		if !ok {
+			errs = append(errs, fmt.Errorf("management device component %#04x references unknown management device %#04x",
+				c.Handle, c.ManagementDeviceHandle))
+			continue
+		}
+
+		c.Component = byHandle[c.ComponentHandle]
+		if c.ThresholdHandle != 0xFFFF {
+			c.Threshold = thresholdByHandle[c.ThresholdHandle]
+		}
+		d.Components = append(d.Components, c)
+	}
+
+	return errs
+}
+
+var Management_Device_Type = []string{ /* 7.35.1 */
+	"Other", /* 0x01 */
+	"Unknown",
+	"LM75",
+	"LM78",
+	"LM79",
+	"LM80",
+	"LM81",
+	"ADM9240",
+	"DS1780",
+	"MAX1617",
+	"GL518SM",
+	"W83781D",
+	"HT82H791", /* 0x0D */
+}
+
+var Management_Device_Address_Type = []string{ /* 7.35.2 */
+	"Other", /* 0x01 */
+	"Unknown",
+	"I/O Port",
+	"Memory",
+	"SMBus", /* 0x05 */
+}
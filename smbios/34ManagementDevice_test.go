@@ -0,0 +1,170 @@
+package smbios_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestParseManagementDevices(t *testing.T) {
+	probe := &smbios.Structure{
+		Header:    smbios.Header{Type: 28, Length: 0x16, Handle: 0x0030},
+		Formatted: make([]byte, 0x12),
+	}
+
+	ss := []*smbios.Structure{
+		{
+			Header:    smbios.Header{Type: 34, Length: 0x0B, Handle: 0x0010},
+			Formatted: []byte{0x01, 0x03, 0x90, 0x00, 0x00, 0x00, 0x05},
+			Strings:   []string{"LM75-1"},
+		},
+		{
+			Header:    smbios.Header{Type: 35, Length: 0x0B, Handle: 0x0020},
+			Formatted: []byte{0x01, 0x10, 0x00, 0x30, 0x00, 0x40, 0x00},
+			Strings:   []string{"CPU Temp"},
+		},
+		{
+			Header:    smbios.Header{Type: 35, Length: 0x0B, Handle: 0x0021},
+			Formatted: []byte{0x00, 0x10, 0x00, 0x31, 0x00, 0xFF, 0xFF},
+		},
+		{
+			Header: smbios.Header{Type: 36, Length: 0x10, Handle: 0x0040},
+			Formatted: []byte{
+				0x00, 0x80, 0x20, 0x03, 0x00, 0x80,
+				0x52, 0x03, 0x00, 0x80, 0x84, 0x03,
+			},
+		},
+		probe,
+	}
+
+	threshold := &smbios.ManagementDeviceThresholdData{
+		Handle:                       0x0040,
+		LowerThresholdNonCritical:    smbios.ThresholdUnavailable,
+		UpperThresholdNonCritical:    800,
+		LowerThresholdCritical:       smbios.ThresholdUnavailable,
+		UpperThresholdCritical:       850,
+		LowerThresholdNonRecoverable: smbios.ThresholdUnavailable,
+		UpperThresholdNonRecoverable: 900,
+	}
+
+	want := []*smbios.ManagementDevice{{
+		Handle:      0x0010,
+		Description: "LM75-1",
+		Type:        "LM75",
+		Address:     0x90,
+		AddressType: "SMBus",
+		Components: []*smbios.ManagementDeviceComponent{
+			{
+				Handle:                 0x0020,
+				Description:            "CPU Temp",
+				ManagementDeviceHandle: 0x0010,
+				ComponentHandle:        0x0030,
+				ThresholdHandle:        0x0040,
+				Component:              probe,
+				Threshold:              threshold,
+			},
+			{
+				Handle:                 0x0021,
+				ManagementDeviceHandle: 0x0010,
+				ComponentHandle:        0x0031,
+				ThresholdHandle:        0xFFFF,
+			},
+		},
+	}}
+
+	got, errs := smbios.ParseManagementDevices(ss)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected management devices (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseManagementDevicesSkipsBadRecords(t *testing.T) {
+	ss := []*smbios.Structure{
+		{
+			Header:    smbios.Header{Type: 34, Length: 0x0B, Handle: 0x0010},
+			Formatted: []byte{0x01, 0x03, 0x90, 0x00, 0x00, 0x00, 0x05},
+			Strings:   []string{"LM75-1"},
+		},
+		// Too short.
+		{
+			Header:    smbios.Header{Type: 34, Length: 0x06, Handle: 0x0011},
+			Formatted: []byte{0x00, 0x03},
+		},
+		{
+			Header:    smbios.Header{Type: 35, Length: 0x0B, Handle: 0x0020},
+			Formatted: []byte{0x00, 0x10, 0x00, 0x30, 0x00, 0xFF, 0xFF},
+		},
+		// References a management device which does not exist.
+		{
+			Header:    smbios.Header{Type: 35, Length: 0x0B, Handle: 0x0021},
+			Formatted: []byte{0x00, 0x12, 0x00, 0x31, 0x00, 0xFF, 0xFF},
+		},
+	}
+
+	got, errs := smbios.ParseManagementDevices(ss)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, but got: %v", errs)
+	}
+
+	if len(got) != 1 || got[0].Handle != 0x0010 {
+		t.Fatalf("unexpected management devices: %v", got)
+	}
+	if len(got[0].Components) != 1 || got[0].Components[0].Handle != 0x0020 {
+		t.Fatalf("unexpected components: %v", got[0].Components)
+	}
+}
+
+func TestLinkManagementDevices(t *testing.T) {
+	d := &smbios.ManagementDevice{Handle: 0x0010}
+	c := &smbios.ManagementDeviceComponent{
+		Handle:                 0x0020,
+		ManagementDeviceHandle: 0x0010,
+		ThresholdHandle:        0x0040,
+	}
+	th := &smbios.ManagementDeviceThresholdData{Handle: 0x0040}
+
+	errs := smbios.LinkManagementDevices([]*smbios.ManagementDevice{d},
+		[]*smbios.ManagementDeviceComponent{c}, []*smbios.ManagementDeviceThresholdData{th}, nil)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	// The same instances are linked, not copies.
+	if len(d.Components) != 1 || d.Components[0] != c || c.Threshold != th {
+		t.Fatalf("unexpected links: %+v", d.Components)
+	}
+}
+
+func TestParseSMBIOSManagementDevices(t *testing.T) {
+	ss := []*smbios.Structure{
+		{
+			Header:    smbios.Header{Type: 34, Length: 0x0B, Handle: 0x0010},
+			Formatted: []byte{0x01, 0x03, 0x90, 0x00, 0x00, 0x00, 0x05},
+			Strings:   []string{"LM75-1"},
+		},
+		{
+			Header:    smbios.Header{Type: 35, Length: 0x0B, Handle: 0x0020},
+			Formatted: []byte{0x00, 0x10, 0x00, 0x30, 0x00, 0xFF, 0xFF},
+		},
+	}
+
+	got := smbios.ParseSMBIOS(nil, ss)
+	if len(got.ManagementDevices) != 1 || len(got.Parsed[34]) != 1 {
+		t.Fatalf("unexpected management devices: %v, %v", got.ManagementDevices, got.Parsed[34])
+	}
+
+	// The tree is built from the registry results, so there is a single
+	// set of objects.
+	d := got.ManagementDevices[0]
+	if d != got.Parsed[34][0] {
+		t.Fatal("ManagementDevices and Parsed[34] hold different instances")
+	}
+	if len(d.Components) != 1 || d.Components[0] != got.Parsed[35][0] {
+		t.Fatalf("unexpected components: %v", d.Components)
+	}
+}
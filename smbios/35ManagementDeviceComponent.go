@@ -0,0 +1,41 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ManagementDeviceComponent 把管理设备和它监控的探针/风扇以及阈值关联起来
+type ManagementDeviceComponent struct { // 7.36 type 35
+	Handle                 uint16
+	Description            string // 4 String number
+	ManagementDeviceHandle uint16 // 5-6 type 34
+	ComponentHandle        uint16 // 7-8 type 26/27/28/29
+	ThresholdHandle        uint16 // 9-10 type 36, FFFFh 表示没有阈值
+	// 以下字段由 LinkManagementDevices 根据 handle 填充
+	Component *Structure
+	Threshold *ManagementDeviceThresholdData
+}
+
+// ParseManagementDeviceComponent 解析structure
+func ParseManagementDeviceComponent(s *Structure) (*ManagementDeviceComponent, error) {
+	if s == nil {
+		return nil, fmt.Errorf("structure s is null")
+	}
+	if s.Header.Type != 35 {
+		return nil, fmt.Errorf("structure s is not type 35, but %d", s.Header.Type)
+	}
+	if len(s.Formatted) < 7 {
+		return nil, fmt.Errorf("structure s is too short for type 35: %d bytes", len(s.Formatted))
+	}
+
+	ret := &ManagementDeviceComponent{
+		Handle:                 s.Header.Handle,
+		Description:            s.StringAt(0),
+		ManagementDeviceHandle: binary.LittleEndian.Uint16(s.Formatted[1:3]),
+		ComponentHandle:        binary.LittleEndian.Uint16(s.Formatted[3:5]),
+		ThresholdHandle:        binary.LittleEndian.Uint16(s.Formatted[5:7]),
+	}
+
+	return ret, nil
+}
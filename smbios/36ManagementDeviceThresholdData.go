@@ -0,0 +1,45 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ThresholdUnavailable 表示阈值不可用
+const ThresholdUnavailable = 0x8000
+
+// ManagementDeviceThresholdData 管理设备组件的阈值，单位和被监控的探针一致，
+// 值为 ThresholdUnavailable 表示不可用
+type ManagementDeviceThresholdData struct { // 7.37 type 36
+	Handle                       uint16
+	LowerThresholdNonCritical    uint16 // 4-5
+	UpperThresholdNonCritical    uint16 // 6-7
+	LowerThresholdCritical       uint16 // 8-9
+	UpperThresholdCritical       uint16 // 10-11
+	LowerThresholdNonRecoverable uint16 // 12-13
+	UpperThresholdNonRecoverable uint16 // 14-15
+}
+
+// ParseManagementDeviceThresholdData 解析structure
+func ParseManagementDeviceThresholdData(s *Structure) (*ManagementDeviceThresholdData, error) {
+	if s == nil {
+		return nil, fmt.Errorf("structure s is null")
+	}
+	if s.Header.Type != 36 {
+		return nil, fmt.Errorf("structure s is not type 36, but %d", s.Header.Type)
+	}
+	if len(s.Formatted) < 12 {
+		return nil, fmt.Errorf("structure s is too short for type 36: %d bytes", len(s.Formatted))
+	}
+
+	f := s.Formatted
+	return &ManagementDeviceThresholdData{
+		Handle:                       s.Header.Handle,
+		LowerThresholdNonCritical:    binary.LittleEndian.Uint16(f[0:2]),
+		UpperThresholdNonCritical:    binary.LittleEndian.Uint16(f[2:4]),
+		LowerThresholdCritical:       binary.LittleEndian.Uint16(f[4:6]),
+		UpperThresholdCritical:       binary.LittleEndian.Uint16(f[6:8]),
+		LowerThresholdNonRecoverable: binary.LittleEndian.Uint16(f[8:10]),
+		UpperThresholdNonRecoverable: binary.LittleEndian.Uint16(f[10:12]),
+	}, nil
+}
@@ -0,0 +1,87 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MemoryChannel 内存通道，描述通道上挂载的内存设备以及各自的负载
+type MemoryChannel struct { // 7.38 type 37
+	Handle             uint16
+	ChannelType        string                 // 4 7.38.1
+	MaximumChannelLoad uint8                  // 5
+	MemoryDeviceCount  uint8                  // 6 n
+	Devices            []*MemoryChannelDevice // 7 n*3
+}
+
+// MemoryChannelDevice 通道上的一个内存设备
+type MemoryChannelDevice struct {
+	Load   uint8  // 0
+	Handle uint16 // 1-2 type 17
+	// MemoryDevice 由 LinkMemoryChannels 根据 Handle 填充
+	MemoryDevice *MemoryDeviceStructure
+}
+
+// ParseMemoryChannel 解析structure
+func ParseMemoryChannel(s *Structure) (*MemoryChannel, error) {
+	if s == nil {
+		return nil, fmt.Errorf("structure s is null")
+	}
+	if s.Header.Type != 37 {
+		return nil, fmt.Errorf("structure s is not type 37, but %d", s.Header.Type)
+	}
+	if len(s.Formatted) < 3 {
+		return nil, fmt.Errorf("structure s is too short for type 37: %d bytes", len(s.Formatted))
+	}
+
+	ret := &MemoryChannel{
+		Handle:             s.Header.Handle,
+		MaximumChannelLoad: s.Formatted[1],
+		MemoryDeviceCount:  s.Formatted[2],
+	}
+
+	t := int(s.Formatted[0])
+	if t > 0 && t <= len(Memory_Channel_Type) {
+		ret.ChannelType = Memory_Channel_Type[t-1]
+	} else {
+		ret.ChannelType = Memory_Channel_Type[1]
+	}
+
+	n := int(ret.MemoryDeviceCount)
+	if len(s.Formatted) < 3+n*3 {
+		return nil, fmt.Errorf("structure s is too short for %d memory devices: %d bytes", n, len(s.Formatted))
+	}
+	for i := 0; i < n; i++ {
+		b := s.Formatted[3+i*3 : 6+i*3]
+		ret.Devices = append(ret.Devices, &MemoryChannelDevice{
+			Load:   b[0],
+			Handle: binary.LittleEndian.Uint16(b[1:3]),
+		})
+	}
+
+	return ret, nil
+}
+
+// LinkMemoryChannels 根据 handle 把通道上的设备和 type 17 内存设备关联起来，
+// 找不到对应内存设备的条目保持为 nil
+func LinkMemoryChannels(channels []*MemoryChannel, devices []*MemoryDeviceStructure) {
+	byHandle := make(map[uint16]*MemoryDeviceStructure, len(devices))
+	for _, d := range devices {
+		if d != nil {
+			byHandle[d.Handle] = d
+		}
+	}
+
+	for _, c := range channels {
+		for _, d := range c.Devices {
+			d.MemoryDevice = byHandle[d.Handle]
+		}
+	}
+}
+
+var Memory_Channel_Type = []string{ /* 7.38.1 */
+	"Other", /* 0x01 */
+	"Unknown",
+	"RamBus",
+	"SyncLink", /* 0x04 */
+}
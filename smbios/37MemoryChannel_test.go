@@ -0,0 +1,47 @@
+package smbios_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestParseMemoryChannel(t *testing.T) {
+	s := &smbios.Structure{
+		Header: smbios.Header{Type: 37, Length: 0x0D, Handle: 0x0050},
+		Formatted: []byte{
+			0x03, 0x10, 0x02,
+			0x08, 0x11, 0x00,
+			0x08, 0x12, 0x00,
+		},
+	}
+
+	ch, err := smbios.ParseMemoryChannel(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dimm := &smbios.MemoryDeviceStructure{Handle: 0x0011}
+	smbios.LinkMemoryChannels([]*smbios.MemoryChannel{ch}, []*smbios.MemoryDeviceStructure{dimm})
+
+	want := &smbios.MemoryChannel{
+		Handle:             0x0050,
+		ChannelType:        "RamBus",
+		MaximumChannelLoad: 0x10,
+		MemoryDeviceCount:  2,
+		Devices: []*smbios.MemoryChannelDevice{
+			{Load: 0x08, Handle: 0x0011, MemoryDevice: dimm},
+			{Load: 0x08, Handle: 0x0012},
+		},
+	}
+
+	if diff := cmp.Diff(want, ch); diff != "" {
+		t.Fatalf("unexpected memory channel (-want +got):\n%s", diff)
+	}
+
+	s.Formatted = s.Formatted[:6]
+	if _, err := smbios.ParseMemoryChannel(s); err == nil {
+		t.Fatal("expected an error for truncated device list, but none occurred")
+	}
+}
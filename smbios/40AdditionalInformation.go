@@ -0,0 +1,134 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// AdditionalInformation 附加信息，厂商用它给其他 structure 的字段补充字符串或取值
+type AdditionalInformation struct { // 7.41 type 40
+	Handle  uint16
+	Entries []*AdditionalInformationEntry // 5 7.41.1
+}
+
+// AdditionalInformationEntry 一条附加信息
+type AdditionalInformationEntry struct { // 7.41.1
+	ReferencedHandle uint16 // 1-2
+	ReferencedOffset uint8  // 3 被引用 structure 中的字段偏移，包含 header
+	String           string // 4 String number
+	Value            []byte // 5 长度为 Entry Length - 5
+}
+
+// ParseAdditionalInformation 解析structure
+func ParseAdditionalInformation(s *Structure) (*AdditionalInformation, error) {
+	if s == nil {
+		return nil, fmt.Errorf("structure s is null")
+	}
+	if s.Header.Type != 40 {
+		return nil, fmt.Errorf("structure s is not type 40, but %d", s.Header.Type)
+	}
+	if len(s.Formatted) < 1 {
+		return nil, fmt.Errorf("structure s is too short for type 40: %d bytes", len(s.Formatted))
+	}
+
+	ret := &AdditionalInformation{Handle: s.Header.Handle}
+
+	n := int(s.Formatted[0])
+	b := s.Formatted[1:]
+	for i := 0; i < n; i++ {
+		if len(b) < 5 {
+			return nil, fmt.Errorf("additional information entry %d is truncated", i)
+		}
+		l := int(b[0])
+		if l < 5 || l > len(b) {
+			return nil, fmt.Errorf("additional information entry %d has invalid length %d", i, l)
+		}
+
+		e := &AdditionalInformationEntry{
+			ReferencedHandle: binary.LittleEndian.Uint16(b[1:3]),
+			ReferencedOffset: b[3],
+		}
+		if sn := int(b[4]); sn > 0 && sn <= len(s.Strings) {
+			e.String = s.Strings[sn-1]
+		}
+		if l > 5 {
+			e.Value = make([]byte, l-5)
+			copy(e.Value, b[5:l])
+		}
+
+		ret.Entries = append(ret.Entries, e)
+		b = b[l:]
+	}
+
+	return ret, nil
+}
+
+// ApplyAdditionalInformation 把附加信息条目挂到被引用的 structure 的 Additional
+// 字段上，返回应用后的 structure 列表，ss 本身不会被修改。ParseSMBIOS 在运行
+// 解析器之前会调用它，解析结果的附加信息通过 SMBIOS.AdditionalAt 查询。
+//
+// 条目中的 Value 是规范尚未收录的取值，String 是给字段补充的字符串，两者都不
+// 会覆盖被引用字段原来的内容，调用方通过 AdditionalAt 按字段查询。引用了未知
+// handle 或 header 的条目会被跳过，原因通过 errs 返回。
+func ApplyAdditionalInformation(ss []*Structure, entries []*AdditionalInformationEntry) (out []*Structure, errs []error) {
+	out = make([]*Structure, len(ss))
+	copy(out, ss)
+
+	index := make(map[uint16]int, len(out))
+	for i, s := range out {
+		index[s.Header.Handle] = i
+	}
+
+	copied := make(map[int]bool)
+	for _, e := range entries {
+		i, ok := index[e.ReferencedHandle]
+		if !ok {
+			errs = append(errs, fmt.Errorf("additional information references unknown handle %#04x", e.ReferencedHandle))
+			continue
+		}
+		if e.ReferencedOffset < headerLen {
+			errs = append(errs, fmt.Errorf("additional information references header offset %d of handle %#04x",
+				e.ReferencedOffset, e.ReferencedHandle))
+			continue
+		}
+
+		// 第一次修改时复制一份，避免修改调用方的数据
+		if !copied[i] {
+			out[i] = copyStructure(out[i])
+			copied[i] = true
+		}
+		out[i].Additional = append(out[i].Additional, e)
+	}
+
+	return out, errs
+}
+
+// AdditionalAt 返回 ApplyAdditionalInformation 挂到 formatted 区域偏移 off 处字段
+// 上的附加信息条目，off 和 StringAt 一样不包含 header
+func (s *Structure) AdditionalAt(off int) []*AdditionalInformationEntry {
+	var out []*AdditionalInformationEntry
+	for _, e := range s.Additional {
+		if int(e.ReferencedOffset)-headerLen == off {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// copyStructure 深拷贝一个 structure
+func copyStructure(s *Structure) *Structure {
+	c := &Structure{Header: s.Header}
+	if s.Formatted != nil {
+		c.Formatted = make([]byte, len(s.Formatted))
+		copy(c.Formatted, s.Formatted)
+	}
+	if s.Strings != nil {
+		c.Strings = make([]string, len(s.Strings))
+		copy(c.Strings, s.Strings)
+	}
+	if s.Additional != nil {
+		c.Additional = make([]*AdditionalInformationEntry, len(s.Additional))
+		copy(c.Additional, s.Additional)
+	}
+	return c
+}
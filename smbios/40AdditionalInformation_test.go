@@ -0,0 +1,118 @@
+package smbios_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestApplyAdditionalInformation(t *testing.T) {
+	mgmt := &smbios.Structure{
+		Header:    smbios.Header{Type: 34, Length: 0x0B, Handle: 0x0010},
+		Formatted: []byte{0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+	}
+	info := &smbios.Structure{
+		Header: smbios.Header{Type: 40, Length: 0x10, Handle: 0x0060},
+		Formatted: []byte{
+			0x02,
+			// Attach a string to the description field.
+			0x05, 0x10, 0x00, 0x04, 0x01,
+			// Override the address type with SMBus.
+			0x06, 0x10, 0x00, 0x0A, 0x00, 0x05,
+		},
+		Strings: []string{"Vendor Sensor"},
+	}
+
+	ai, err := smbios.ParseAdditionalInformation(info)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantEntries := []*smbios.AdditionalInformationEntry{
+		{ReferencedHandle: 0x0010, ReferencedOffset: 0x04, String: "Vendor Sensor"},
+		{ReferencedHandle: 0x0010, ReferencedOffset: 0x0A, Value: []byte{0x05}},
+	}
+	if diff := cmp.Diff(wantEntries, ai.Entries); diff != "" {
+		t.Fatalf("unexpected entries (-want +got):\n%s", diff)
+	}
+
+	entries := append(ai.Entries,
+		// Neither the header nor an unknown handle can be referenced.
+		&smbios.AdditionalInformationEntry{ReferencedHandle: 0x0010, ReferencedOffset: 0x02, Value: []byte{0x00}},
+		&smbios.AdditionalInformationEntry{ReferencedHandle: 0x0099, ReferencedOffset: 0x04, String: "Missing"},
+	)
+
+	ss, errs := smbios.ApplyAdditionalInformation([]*smbios.Structure{mgmt, info}, entries)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, but got: %v", errs)
+	}
+	if ss[0] == mgmt || mgmt.Additional != nil {
+		t.Fatal("input structure was modified in place")
+	}
+
+	// The referenced fields keep their values, the entries are attached.
+	if diff := cmp.Diff(mgmt.Formatted, ss[0].Formatted); diff != "" {
+		t.Fatalf("referenced structure was patched (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantEntries[:1], ss[0].AdditionalAt(0)); diff != "" {
+		t.Fatalf("unexpected description entries (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantEntries[1:], ss[0].AdditionalAt(6)); diff != "" {
+		t.Fatalf("unexpected address type entries (-want +got):\n%s", diff)
+	}
+	if got := ss[0].AdditionalAt(1); got != nil {
+		t.Fatalf("unexpected type entries: %v", got)
+	}
+
+	d, err := smbios.ParseManagementDevice(ss[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Type != "Unknown" || d.AddressType != "Unknown" {
+		t.Fatalf("referenced fields were overwritten: %+v", d)
+	}
+}
+
+func TestParseSMBIOSAdditionalInformation(t *testing.T) {
+	ss := []*smbios.Structure{
+		{
+			Header:    smbios.Header{Type: 0, Length: 0x18, Handle: 0x0000},
+			Formatted: append([]byte{0x01, 0x02, 0x00, 0xE8, 0x03, 0x00}, make([]byte, 14)...),
+			Strings:   []string{"Dell Inc.", "2.10.2", "01/01/2023"},
+		},
+		{
+			Header: smbios.Header{Type: 40, Length: 0x0B, Handle: 0x0060},
+			Formatted: []byte{
+				0x01,
+				// Attach a string to the BIOS version field.
+				0x05, 0x00, 0x00, 0x05, 0x01,
+			},
+			Strings: []string{"Vendor Build 7"},
+		},
+	}
+
+	got := smbios.ParseSMBIOS(nil, ss)
+	if got.BIOSInformation == nil {
+		t.Fatal("no BIOS information")
+	}
+	if v := got.BIOSInformation.BIOSVersion; v != "2.10.2" {
+		t.Fatalf("referenced field was overwritten: %q", v)
+	}
+
+	want := []*smbios.AdditionalInformationEntry{
+		{ReferencedHandle: 0x0000, ReferencedOffset: 0x05, String: "Vendor Build 7"},
+	}
+	if diff := cmp.Diff(want, got.AdditionalAt(got.BIOSInformation, 1)); diff != "" {
+		t.Fatalf("unexpected BIOS version entries (-want +got):\n%s", diff)
+	}
+	if src := got.Source(got.BIOSInformation); src == nil || src == ss[0] {
+		t.Fatalf("unexpected source structure: %v", src)
+	}
+	if ss[0].Additional != nil {
+		t.Fatal("input structure was modified in place")
+	}
+	if len(got.AdditionalInformation) != 1 {
+		t.Fatalf("unexpected additional information: %v", got.AdditionalInformation)
+	}
+}
@@ -18,7 +18,13 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"strings"
 )
 
 const (
@@ -35,10 +41,72 @@ var (
 	endStringSet = []byte{0x00, 0x00}
 )
 
+// ErrMissingEndOfTable is reported by DecodeWith when the table ends
+// without an End-of-table structure.
+var ErrMissingEndOfTable = errors.New("smbios: missing end-of-table structure")
+
+// A TrailingDataError is reported by DecodeWith when non-zero data follows
+// the last structure of the table.
+type TrailingDataError struct {
+	// Offset is the offset of the trailing data from the start of the table.
+	Offset int
+	// Length is the number of trailing bytes, including any zero padding.
+	Length int
+}
+
+func (e *TrailingDataError) Error() string {
+	return fmt.Sprintf("smbios: %d bytes of trailing data at table offset %d", e.Length, e.Offset)
+}
+
+// DecodeOptions controls how DecodeWith decodes a table.  The zero value
+// decodes until an End-of-table structure or the end of the stream.
+type DecodeOptions struct {
+	// MaxStructures stops decoding after this many structures, if non-zero.
+	MaxStructures int
+
+	// MaxSize stops decoding after this many bytes, if non-zero.  A
+	// structure crossing this boundary is an error.
+	MaxSize int
+
+	// RequireEndOfTable makes ErrMissingEndOfTable an error instead of a
+	// warning.
+	RequireEndOfTable bool
+
+	// RejectTrailingData makes a TrailingDataError an error instead of a
+	// warning.
+	RejectTrailingData bool
+}
+
+// EntryPointDecodeOptions returns the DecodeOptions which honor the table
+// limits of ep: the structure count and table length of a 2.x entry point,
+// or the maximum table size of a 3.x entry point.
+func EntryPointDecodeOptions(ep EntryPoint) *DecodeOptions {
+	switch ep := ep.(type) {
+	case *EntryPoint32Bit:
+		return &DecodeOptions{
+			MaxStructures: int(ep.NumberStructures),
+			MaxSize:       int(ep.StructureTableLength),
+		}
+	case *LegacyDMIEntryPoint:
+		return &DecodeOptions{
+			MaxStructures: int(ep.NumberStructures),
+			MaxSize:       int(ep.StructureTableLength),
+		}
+	case nil:
+		return &DecodeOptions{}
+	default:
+		_, size := ep.Table()
+		return &DecodeOptions{MaxSize: size}
+	}
+}
+
 // A Decoder decodes Structures from a stream.
 type Decoder struct {
 	br *bufio.Reader
 	b  []byte
+
+	// off is the number of bytes consumed from the stream.
+	off int
 }
 
 // Stream locates and opens a stream of SMBIOS data and the SMBIOS entry
@@ -47,7 +115,47 @@ type Decoder struct {
 //
 // If no suitable location is found, an error is returned.
 func Stream() (io.ReadCloser, EntryPoint, error) {
-	rc, ep, err := stream()
+	return StreamWithOptions(nil)
+}
+
+// StreamOptions controls where StreamWithOptions looks for SMBIOS data.
+type StreamOptions struct {
+	// Root is the directory under which operating system paths such as
+	// /sys/firmware/dmi/tables/DMI and /dev/mem are resolved, for example
+	// "/host" when the host filesystem is mounted into a container.  An
+	// empty Root means "/".
+	Root string
+
+	// FS, if not nil, is used instead of Root to open operating system
+	// paths, which are passed to it without their leading slash.  Memory
+	// devices opened through FS must implement io.ReadSeeker.
+	FS fs.FS
+}
+
+// fsys returns the filesystem used to open operating system paths.
+func (o *StreamOptions) fsys() fs.FS {
+	switch {
+	case o == nil:
+		return os.DirFS("/")
+	case o.FS != nil:
+		return o.FS
+	case o.Root != "":
+		return os.DirFS(o.Root)
+	default:
+		return os.DirFS("/")
+	}
+}
+
+// fsPath converts an absolute operating system path to an fs.FS path.
+func fsPath(p string) string {
+	return strings.TrimPrefix(p, "/")
+}
+
+// StreamWithOptions is like Stream, but resolves operating system paths
+// according to opts.  A nil opts is the same as the zero value.  On Windows
+// the table is read through a system call and opts has no effect.
+func StreamWithOptions(opts *StreamOptions) (io.ReadCloser, EntryPoint, error) {
+	rc, ep, err := stream(opts.fsys())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -90,6 +198,90 @@ func (d *Decoder) Decode() ([]*Structure, error) {
 	return ss, nil
 }
 
+// DecodeWith decodes Structures from the Decoder's stream, stopping at the
+// limits set by opts or at an End-of-table structure, whichever comes first.
+//
+// Problems which do not prevent the structures from being decoded, such as
+// ErrMissingEndOfTable or a *TrailingDataError, are returned as warnings
+// unless opts makes them errors.  Decoding stops without an error at zero
+// padding or at a header whose length is too short for a structure.  A nil opts is the same as the zero value.
+func (d *Decoder) DecodeWith(opts *DecodeOptions) ([]*Structure, []error, error) {
+	if opts == nil {
+		opts = &DecodeOptions{}
+	}
+
+	var (
+		ss       []*Structure
+		warnings []error
+		end      bool
+	)
+
+	for {
+		if opts.MaxStructures > 0 && len(ss) >= opts.MaxStructures {
+			break
+		}
+		if opts.MaxSize > 0 && d.off >= opts.MaxSize {
+			break
+		}
+
+		// A clean end of stream between structures ends the table.
+		hb, err := d.br.Peek(headerLen)
+		if len(hb) == 0 && err == io.EOF {
+			break
+		}
+
+		// So does a header which cannot start a structure, such as the zero
+		// padding of a 3.x table without an End-of-table structure.  What
+		// remains is checked as trailing data below.
+		if len(bytes.Trim(hb, "\x00")) == 0 || (len(hb) == headerLen && hb[1] < headerLen) {
+			break
+		}
+
+		off := d.off
+		s, err := d.next()
+		if err != nil {
+			return nil, nil, err
+		}
+		if opts.MaxSize > 0 && d.off > opts.MaxSize {
+			return nil, nil, fmt.Errorf("structure at table offset %d exceeds table size %d", off, opts.MaxSize)
+		}
+
+		ss = append(ss, s)
+		if s.Header.Type == typeEndOfTable {
+			end = true
+			break
+		}
+	}
+
+	if !end {
+		if opts.RequireEndOfTable {
+			return nil, nil, ErrMissingEndOfTable
+		}
+		warnings = append(warnings, ErrMissingEndOfTable)
+	}
+
+	// Anything left within the table is trailing data.  Zero padding up to
+	// the maximum size of a 3.x table is expected and is not reported.
+	var r io.Reader = d.br
+	if opts.MaxSize > 0 {
+		r = io.LimitReader(d.br, int64(opts.MaxSize-d.off))
+	}
+	rest, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(bytes.Trim(rest, "\x00")) > 0 {
+		terr := &TrailingDataError{Offset: d.off, Length: len(rest)}
+		if opts.RejectTrailingData {
+			return nil, nil, terr
+		}
+		warnings = append(warnings, terr)
+	}
+	d.off += len(rest)
+
+	return ss, warnings, nil
+}
+
 // next decodes the next Structure from the stream.
 func (d *Decoder) next() (*Structure, error) {
 	h, err := d.parseHeader()
@@ -122,6 +314,7 @@ func (d *Decoder) parseHeader() (*Header, error) {
 	if _, err := io.ReadFull(d.br, d.b[:headerLen]); err != nil {
 		return nil, err
 	}
+	d.off += headerLen
 
 	return &Header{
 		Type:   d.b[0],
@@ -144,6 +337,7 @@ func (d *Decoder) parseFormatted(l int) ([]byte, error) {
 	if _, err := io.ReadFull(d.br, d.b[:l]); err != nil {
 		return nil, err
 	}
+	d.off += l
 
 	// Make a copy to free up the internal buffer.
 	fb := make([]byte, len(d.b[:l]))
@@ -165,6 +359,7 @@ func (d *Decoder) parseStrings() ([]string, error) {
 		if _, err := d.br.Discard(2); err != nil {
 			return nil, err
 		}
+		d.off += 2
 
 		return nil, nil
 	}
@@ -197,6 +392,7 @@ func (d *Decoder) parseString() (str string, more bool, err error) {
 	if err != nil {
 		return "", false, err
 	}
+	d.off += len(raw)
 
 	b := bytes.TrimRight(raw, "\x00")
 
@@ -215,6 +411,7 @@ func (d *Decoder) parseString() (str string, more bool, err error) {
 	if _, err := d.br.Discard(1); err != nil {
 		return "", false, err
 	}
+	d.off++
 
 	return string(b), false, nil
 }
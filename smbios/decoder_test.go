@@ -190,3 +190,192 @@ func TestDecoder(t *testing.T) {
 		})
 	}
 }
+
+func TestDecoderDecodeWith(t *testing.T) {
+	var (
+		bios = []byte{
+			0x00, 0x05, 0x01, 0x00,
+			0xff,
+			0x00,
+			0x00,
+		}
+		system = []byte{
+			0x01, 0x04, 0x02, 0x00,
+			'a', 0x00,
+			0x00,
+		}
+		eot = []byte{
+			127, 0x04, 0x03, 0x00,
+			0x00,
+			0x00,
+		}
+	)
+
+	join := func(bs ...[]byte) []byte { return bytes.Join(bs, nil) }
+
+	tests := []struct {
+		name     string
+		b        []byte
+		opts     *smbios.DecodeOptions
+		types    []uint8
+		warnings []string
+		ok       bool
+	}{
+		{
+			name:  "nil options",
+			b:     join(bios, system, eot),
+			types: []uint8{0, 1, 127},
+			ok:    true,
+		},
+		{
+			name:  "structure count",
+			b:     join(bios, system, eot),
+			opts:  &smbios.DecodeOptions{MaxStructures: 2},
+			types: []uint8{0, 1},
+			warnings: []string{
+				smbios.ErrMissingEndOfTable.Error(),
+				"smbios: 6 bytes of trailing data at table offset 14",
+			},
+			ok: true,
+		},
+		{
+			name:     "missing end of table",
+			b:        join(bios, system),
+			types:    []uint8{0, 1},
+			warnings: []string{smbios.ErrMissingEndOfTable.Error()},
+			ok:       true,
+		},
+		{
+			name: "missing end of table required",
+			b:    join(bios, system),
+			opts: &smbios.DecodeOptions{RequireEndOfTable: true},
+		},
+		{
+			name:  "max size with zero padding",
+			b:     join(bios, eot, make([]byte, 32), system),
+			opts:  &smbios.DecodeOptions{MaxSize: 45},
+			types: []uint8{0, 127},
+			ok:    true,
+		},
+		{
+			name:     "max size stops before end of table",
+			b:        join(bios, system, eot),
+			opts:     &smbios.DecodeOptions{MaxSize: 7},
+			types:    []uint8{0},
+			warnings: []string{smbios.ErrMissingEndOfTable.Error()},
+			ok:       true,
+		},
+		{
+			name: "structure exceeds max size",
+			b:    join(bios, system, eot),
+			opts: &smbios.DecodeOptions{MaxSize: 10},
+		},
+		{
+			name:     "missing end of table with zero padding",
+			b:        join(bios, system, make([]byte, 32)),
+			opts:     &smbios.DecodeOptions{MaxSize: 46},
+			types:    []uint8{0, 1},
+			warnings: []string{smbios.ErrMissingEndOfTable.Error()},
+			ok:       true,
+		},
+		{
+			name:     "missing end of table with short padding",
+			b:        join(bios, system, []byte{0x00, 0x00}),
+			types:    []uint8{0, 1},
+			warnings: []string{smbios.ErrMissingEndOfTable.Error()},
+			ok:       true,
+		},
+		{
+			name:  "missing end of table with invalid header length",
+			b:     join(bios, system, []byte{0xde, 0x02, 0xad, 0x00, 0xff}),
+			types: []uint8{0, 1},
+			warnings: []string{
+				smbios.ErrMissingEndOfTable.Error(),
+				"smbios: 5 bytes of trailing data at table offset 14",
+			},
+			ok: true,
+		},
+		{
+			name:     "trailing data",
+			b:        join(bios, eot, []byte{0x00, 0xde, 0xad}),
+			types:    []uint8{0, 127},
+			warnings: []string{"smbios: 3 bytes of trailing data at table offset 13"},
+			ok:       true,
+		},
+		{
+			name: "trailing data rejected",
+			b:    join(bios, eot, []byte{0xde, 0xad}),
+			opts: &smbios.DecodeOptions{RejectTrailingData: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss, warnings, err := smbios.NewDecoder(bytes.NewReader(tt.b)).DecodeWith(tt.opts)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			var types []uint8
+			for _, s := range ss {
+				types = append(types, s.Header.Type)
+			}
+			if diff := cmp.Diff(tt.types, types); diff != "" {
+				t.Fatalf("unexpected structure types (-want +got):\n%s", diff)
+			}
+
+			var ws []string
+			for _, w := range warnings {
+				ws = append(ws, w.Error())
+			}
+			if diff := cmp.Diff(tt.warnings, ws); diff != "" {
+				t.Fatalf("unexpected warnings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntryPointDecodeOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		ep   smbios.EntryPoint
+		opts *smbios.DecodeOptions
+	}{
+		{
+			name: "32-bit",
+			ep:   &smbios.EntryPoint32Bit{NumberStructures: 64, StructureTableLength: 0x0f5f},
+			opts: &smbios.DecodeOptions{MaxStructures: 64, MaxSize: 0x0f5f},
+		},
+		{
+			name: "legacy DMI",
+			ep:   &smbios.LegacyDMIEntryPoint{NumberStructures: 12, StructureTableLength: 0x0200},
+			opts: &smbios.DecodeOptions{MaxStructures: 12, MaxSize: 0x0200},
+		},
+		{
+			name: "64-bit",
+			ep:   &smbios.EntryPoint64Bit{StructureTableMaxSize: 0x0953},
+			opts: &smbios.DecodeOptions{MaxSize: 0x0953},
+		},
+		{
+			name: "nil",
+			opts: &smbios.DecodeOptions{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.opts, smbios.EntryPointDecodeOptions(tt.ep)); diff != "" {
+				t.Fatalf("unexpected options (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package smbios
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DMIEntriesRoot is the directory where Linux exports each structure of the
+// SMBIOS table as <type>-<instance>/raw, along with its handle, length,
+// type and position in the table.
+const DMIEntriesRoot = "/sys/firmware/dmi/entries"
+
+// ReadDMIEntries 从 root（通常是 DMIEntriesRoot）下的每个条目读取 structure，
+// 按照在表中的位置排序返回。types 不为空时只读取这些类型的条目。
+//
+// 条目的 raw 文件包含完整的 structure，包括字符串集合。
+func ReadDMIEntries(root string, types ...uint8) ([]*Structure, error) {
+	return readDMIEntries(os.DirFS(root), ".", root, types)
+}
+
+// ReadDMIEntriesFS 与 ReadDMIEntries 相同，但从 fsys 的 dir 目录读取条目，
+// dir 是 fs.FS 路径，例如 "sys/firmware/dmi/entries"
+func ReadDMIEntriesFS(fsys fs.FS, dir string, types ...uint8) ([]*Structure, error) {
+	return readDMIEntries(fsys, dir, dir, types)
+}
+
+// readDMIEntries 读取 fsys 中 dir 下的条目，name 用于错误信息
+func readDMIEntries(fsys fs.FS, dir, name string, types []uint8) ([]*Structure, error) {
+	dirs, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	want := make(map[uint8]bool, len(types))
+	for _, t := range types {
+		want[t] = true
+	}
+
+	type entry struct {
+		position int
+		s        *Structure
+	}
+	var entries []entry
+
+	for _, d := range dirs {
+		t, ok := parseDMIEntryName(d.Name())
+		if !ok {
+			continue
+		}
+		if len(want) > 0 && !want[t] {
+			continue
+		}
+
+		e := dmiEntry{fsys: fsys, dir: path.Join(dir, d.Name()), name: filepath.Join(name, d.Name())}
+		s, err := e.read()
+		if err != nil {
+			return nil, err
+		}
+		if s.Header.Type != t {
+			return nil, fmt.Errorf("DMI entry %s contains a structure of type %d", e.name, s.Header.Type)
+		}
+
+		position, err := e.readInt("position")
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry{position: position, s: s})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].position < entries[j].position })
+
+	ss := make([]*Structure, 0, len(entries))
+	for _, e := range entries {
+		ss = append(ss, e.s)
+	}
+	return ss, nil
+}
+
+// parseDMIEntryName 解析 "<type>-<instance>" 格式的目录名，返回类型
+func parseDMIEntryName(name string) (uint8, bool) {
+	parts := strings.SplitN(name, "-", 2)
+	if len(parts) != 2 {
+		return 0, false
+	}
+	t, err := strconv.ParseUint(parts[0], 10, 8)
+	if err != nil {
+		return 0, false
+	}
+	if _, err := strconv.Atoi(parts[1]); err != nil {
+		return 0, false
+	}
+	return uint8(t), true
+}
+
+// dmiEntry 是 fsys 中的一个条目目录 dir，name 用于错误信息
+type dmiEntry struct {
+	fsys fs.FS
+	dir  string
+	name string
+}
+
+// read 解析条目的 raw 文件，并用 handle 和 length 文件校验
+func (e dmiEntry) read() (*Structure, error) {
+	raw, err := fs.ReadFile(e.fsys, path.Join(e.dir, "raw"))
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := NewDecoder(bytes.NewReader(raw)).next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode DMI entry %s: %v", e.name, err)
+	}
+
+	handle, err := e.readInt("handle")
+	if err != nil {
+		return nil, err
+	}
+	length, err := e.readInt("length")
+	if err != nil {
+		return nil, err
+	}
+	if handle != int(s.Header.Handle) || length != int(s.Header.Length) {
+		return nil, fmt.Errorf("DMI entry %s has handle %d and length %d, but raw structure has handle %d and length %d",
+			e.name, handle, length, s.Header.Handle, s.Header.Length)
+	}
+
+	return s, nil
+}
+
+// readInt 读取条目中一个十进制整数属性文件
+func (e dmiEntry) readInt(attr string) (int, error) {
+	b, err := fs.ReadFile(e.fsys, path.Join(e.dir, attr))
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid DMI entry attribute %s: %v", filepath.Join(e.name, attr), err)
+	}
+	return v, nil
+}
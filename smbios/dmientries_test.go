@@ -0,0 +1,119 @@
+package smbios_test
+
+import (
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestReadDMIEntries(t *testing.T) {
+	bios := &smbios.Structure{
+		Header:    smbios.Header{Type: 0, Length: 5, Handle: 0x0000},
+		Formatted: []byte{0xff},
+	}
+	system := &smbios.Structure{
+		Header:    smbios.Header{Type: 1, Length: 12, Handle: 0x0001},
+		Formatted: []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		Strings:   []string{"ACME"},
+	}
+	dimmA := &smbios.Structure{
+		Header:    smbios.Header{Type: 17, Length: 6, Handle: 0x0010},
+		Formatted: []byte{0x01, 0x02},
+		Strings:   []string{"DIMM A1"},
+	}
+	dimmB := &smbios.Structure{
+		Header:    smbios.Header{Type: 17, Length: 6, Handle: 0x0011},
+		Formatted: []byte{0x01, 0x02},
+		Strings:   []string{"DIMM B1"},
+	}
+	eot := &smbios.Structure{
+		Header: smbios.Header{Type: 127, Length: 4, Handle: 0xfffe},
+	}
+
+	tests := []struct {
+		name  string
+		types []uint8
+		want  []*smbios.Structure
+	}{
+		{
+			name: "all",
+			want: []*smbios.Structure{bios, system, dimmA, dimmB, eot},
+		},
+		{
+			name:  "memory devices",
+			types: []uint8{17},
+			want:  []*smbios.Structure{dimmA, dimmB},
+		},
+		{
+			name:  "absent type",
+			types: []uint8{4},
+			want:  []*smbios.Structure{},
+		},
+	}
+
+	// The entries as Linux exports them, listed out of table order.
+	fsys := fstest.MapFS{}
+	addDMIEntry(fsys, "17-1", 3, []byte{17, 6, 0x11, 0x00, 0x01, 0x02, 'D', 'I', 'M', 'M', ' ', 'B', '1', 0x00, 0x00})
+	addDMIEntry(fsys, "0-0", 0, []byte{0, 5, 0x00, 0x00, 0xff, 0x00, 0x00})
+	addDMIEntry(fsys, "17-0", 2, []byte{17, 6, 0x10, 0x00, 0x01, 0x02, 'D', 'I', 'M', 'M', ' ', 'A', '1', 0x00, 0x00})
+	addDMIEntry(fsys, "1-0", 1, []byte{1, 12, 0x01, 0x00, 0x01, 0, 0, 0, 0, 0, 0, 0, 'A', 'C', 'M', 'E', 0x00, 0x00})
+	addDMIEntry(fsys, "127-0", 4, []byte{127, 4, 0xfe, 0xff, 0x00, 0x00})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := smbios.ReadDMIEntriesFS(fsys, "sys/firmware/dmi/entries", tt.types...)
+			if err != nil {
+				t.Fatalf("failed to read DMI entries: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected structures (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadDMIEntriesRoot(t *testing.T) {
+	got, err := smbios.ReadDMIEntries("testdata/dmi/entries", 17)
+	if err != nil {
+		t.Fatalf("failed to read DMI entries: %v", err)
+	}
+	if len(got) != 2 || got[0].Header.Handle != 0x0010 || got[1].Header.Handle != 0x0011 {
+		t.Fatalf("unexpected structures: %v", got)
+	}
+}
+
+func TestReadDMIEntriesMismatch(t *testing.T) {
+	fsys := fstest.MapFS{}
+	addDMIEntry(fsys, "0-0", 0, []byte{0, 5, 0x00, 0x00, 0xff, 0x00, 0x00})
+	fsys["sys/firmware/dmi/entries/0-0/handle"] = &fstest.MapFile{Data: []byte("7\n")}
+
+	if _, err := smbios.ReadDMIEntriesFS(fsys, "sys/firmware/dmi/entries"); err == nil {
+		t.Fatal("expected an error for a mismatched handle, but none occurred")
+	}
+}
+
+// addDMIEntry adds the attribute files of a DMI entry for the structure raw
+// at position in the table to fsys.
+func addDMIEntry(fsys fstest.MapFS, name string, position int, raw []byte) {
+	dir := "sys/firmware/dmi/entries/" + name
+	attr := func(v int) *fstest.MapFile { return &fstest.MapFile{Data: []byte(fmt.Sprintf("%d\n", v))} }
+
+	fsys[dir+"/raw"] = &fstest.MapFile{Data: raw}
+	fsys[dir+"/type"] = attr(int(raw[0]))
+	fsys[dir+"/length"] = attr(int(raw[1]))
+	fsys[dir+"/handle"] = attr(int(raw[2]) | int(raw[3])<<8)
+	fsys[dir+"/position"] = attr(position)
+}
+
+func TestReadDMIEntriesMissing(t *testing.T) {
+	if _, err := smbios.ReadDMIEntries("testdata/dmi/missing"); err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+	if _, err := smbios.ReadDMIEntriesFS(fstest.MapFS{}, "sys/firmware/dmi/entries"); err == nil {
+		t.Fatal("expected an error for an empty fs.FS, but none occurred")
+	}
+}
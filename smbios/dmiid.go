@@ -0,0 +1,117 @@
+package smbios
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// DMIIDRoot is the directory where Linux exports the identification strings
+// of the SMBIOS table as world-readable attributes.
+const DMIIDRoot = "/sys/class/dmi/id"
+
+// ReadDMIID 从 root（通常是 DMIIDRoot）下的属性文件构造一个不完整的 SMBIOS，
+// 只包含 BIOSInformation、SystemInformation、BaseboardInformation 和
+// SystemEnclosure 中能从属性文件得到的字段，返回值的 Partial 为 true。
+//
+// 这些属性文件不需要 root 权限就能读取（序列号和 UUID 除外，读取失败的
+// 字段保持为空），适合在没有权限读取 SMBIOS 表时使用。
+func ReadDMIID(root string) (*SMBIOS, error) {
+	return readDMIID(os.DirFS(root), ".", root)
+}
+
+// ReadDMIIDFS 与 ReadDMIID 相同，但从 fsys 的 dir 目录读取属性文件，
+// dir 是 fs.FS 路径，例如 "sys/class/dmi/id"
+func ReadDMIIDFS(fsys fs.FS, dir string) (*SMBIOS, error) {
+	return readDMIID(fsys, dir, dir)
+}
+
+// readDMIID 读取 fsys 中 dir 下的属性文件，name 用于错误信息
+func readDMIID(fsys fs.FS, dir, name string) (*SMBIOS, error) {
+	if _, err := fs.Stat(fsys, dir); err != nil {
+		return nil, err
+	}
+
+	var found bool
+	read := func(name string) string {
+		b, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+		found = true
+		return strings.TrimSpace(string(b))
+	}
+
+	bios := &BIOSInformation{
+		Vendor:          read("bios_vendor"),
+		BIOSVersion:     read("bios_version"),
+		BIOSReleaseDate: read("bios_date"),
+	}
+	bios.SystemBIOSMajorRelease, bios.SystemBIOSMinorRelease = parseDMIIDRelease(read("bios_release"))
+	bios.EmbeddedControllerFirmwareMajorRelease, bios.EmbeddedControllerFirmwareMinorRelease =
+		parseDMIIDRelease(read("ec_firmware_release"))
+
+	system := &SystemInformation{
+		Manufacturer: read("sys_vendor"),
+		ProductName:  read("product_name"),
+		Version:      read("product_version"),
+		SerialNumber: read("product_serial"),
+		UUID:         read("product_uuid"),
+		SKUNumber:    read("product_sku"),
+		Family:       read("product_family"),
+	}
+
+	board := &BaseboardInformation{
+		Manufacturer: read("board_vendor"),
+		ProductName:  read("board_name"),
+		Version:      read("board_version"),
+		SerialNumber: read("board_serial"),
+		AssetTag:     read("board_asset_tag"),
+	}
+
+	enclosure := &SystemEnclosure{
+		Manufacturer: read("chassis_vendor"),
+		Version:      read("chassis_version"),
+		SerialNumber: read("chassis_serial"),
+		AssetTag:     read("chassis_asset_tag"),
+	}
+	// 内核导出 chassis_type 时已经屏蔽了 bit 7，无法得到 ChassisLockPresent
+	if t, err := strconv.ParseUint(read("chassis_type"), 10, 8); err == nil {
+		enclosure.Type = ChassisType(t & 0x7F)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no DMI identification attributes found in %s", name)
+	}
+
+	return &SMBIOS{
+		Partial:               true,
+		BIOSInformation:       bios,
+		SystemInformation:     system,
+		BaseboardInformations: []*BaseboardInformation{board},
+		SystemEnclosures:      []*SystemEnclosure{enclosure},
+		Parsed: map[uint8][]interface{}{
+			0: {bios},
+			1: {system},
+			2: {board},
+			3: {enclosure},
+		},
+	}, nil
+}
+
+// parseDMIIDRelease 解析 "major.minor" 格式的版本号，无法解析时返回 0
+func parseDMIIDRelease(s string) (major, minor uint8) {
+	parts := strings.SplitN(s, ".", 2)
+	if len(parts) != 2 {
+		return 0, 0
+	}
+	ma, err1 := strconv.ParseUint(parts[0], 10, 8)
+	mi, err2 := strconv.ParseUint(parts[1], 10, 8)
+	if err1 != nil || err2 != nil {
+		return 0, 0
+	}
+	return uint8(ma), uint8(mi)
+}
@@ -0,0 +1,109 @@
+package smbios_test
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestReadDMIID(t *testing.T) {
+	// The fixture lacks the root-only serial number and UUID attributes,
+	// as seen by an unprivileged user.
+	got, err := smbios.ReadDMIID("testdata/dmi/id")
+	if err != nil {
+		t.Fatalf("failed to read DMI identification: %v", err)
+	}
+
+	bios := &smbios.BIOSInformation{
+		Vendor:                 "Dell Inc.",
+		BIOSVersion:            "2.17.1",
+		BIOSReleaseDate:        "11/09/2022",
+		SystemBIOSMajorRelease: 2,
+		SystemBIOSMinorRelease: 17,
+	}
+	system := &smbios.SystemInformation{
+		Manufacturer: "Dell Inc.",
+		ProductName:  "PowerEdge R640",
+		SKUNumber:    "SKU=0716;ModelName=PowerEdge R640",
+		Family:       "PowerEdge",
+	}
+	board := &smbios.BaseboardInformation{
+		Manufacturer: "Dell Inc.",
+		ProductName:  "0W23H8",
+		Version:      "A01",
+	}
+	enclosure := &smbios.SystemEnclosure{
+		Manufacturer: "Dell Inc.",
+		Type:         smbios.ChassisType(23),
+	}
+
+	want := &smbios.SMBIOS{
+		Partial:               true,
+		BIOSInformation:       bios,
+		SystemInformation:     system,
+		BaseboardInformations: []*smbios.BaseboardInformation{board},
+		SystemEnclosures:      []*smbios.SystemEnclosure{enclosure},
+		Parsed: map[uint8][]interface{}{
+			0: {bios},
+			1: {system},
+			2: {board},
+			3: {enclosure},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected SMBIOS (-want +got):\n%s", diff)
+	}
+	if s := got.SystemEnclosures[0].Type.String(); s != "Rack Mount Chassis" {
+		t.Fatalf("unexpected chassis type: %s", s)
+	}
+}
+
+func TestReadDMIIDMissing(t *testing.T) {
+	if _, err := smbios.ReadDMIID("testdata/dmi/missing"); err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+	if _, err := smbios.ReadDMIID("testdata/efi"); err == nil {
+		t.Fatal("expected an error for a directory without attributes, but none occurred")
+	}
+}
+
+func TestReadDMIIDFS(t *testing.T) {
+	want, err := smbios.ReadDMIID("testdata/dmi/id")
+	if err != nil {
+		t.Fatalf("failed to read DMI identification: %v", err)
+	}
+
+	got, err := smbios.ReadDMIIDFS(os.DirFS("testdata"), "dmi/id")
+	if err != nil {
+		t.Fatalf("failed to read DMI identification from fs.FS: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected SMBIOS (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadDMIIDChassisLock(t *testing.T) {
+	// The kernel masks chassis_type with 0x7F, so the lock bit can not be
+	// trusted even when it appears to be set.
+	fsys := fstest.MapFS{
+		"sys/class/dmi/id/chassis_type": &fstest.MapFile{Data: []byte("151\n")},
+	}
+
+	got, err := smbios.ReadDMIIDFS(fsys, "sys/class/dmi/id")
+	if err != nil {
+		t.Fatalf("failed to read DMI identification: %v", err)
+	}
+
+	e := got.SystemEnclosures[0]
+	if e.Type != smbios.ChassisType(23) {
+		t.Fatalf("unexpected chassis type: %d", e.Type)
+	}
+	if e.ChassisLockPresent {
+		t.Fatal("chassis lock present was set from chassis_type")
+	}
+}
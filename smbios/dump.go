@@ -0,0 +1,229 @@
+package smbios
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// dumpTableOffset is the offset of the structure table in a dump file.
+// dmidecode rewrites the table address in the entry point to this value.
+const dumpTableOffset = 32
+
+// OpenDump opens a dump file written by "dmidecode --dump-bin" or WriteDump,
+// returning its structure stream and entry point like Stream does.
+func OpenDump(path string) (io.ReadCloser, EntryPoint, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ep, err := ParseEntryPoint(bytes.NewReader(b))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addr, size := ep.Table()
+	if addr < 0 || addr > len(b) {
+		return nil, nil, fmt.Errorf("dump table address %#x is outside of the %d byte dump", addr, len(b))
+	}
+
+	// The size of a 3.x table is only a maximum.
+	end := addr + size
+	if _, ok := ep.(*EntryPoint64Bit); ok && end > len(b) {
+		end = len(b)
+	}
+	if end > len(b) {
+		return nil, nil, fmt.Errorf("dump table of %d bytes at %#x exceeds the %d byte dump", size, addr, len(b))
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(b[addr:end])), ep, nil
+}
+
+// WriteDump writes ep and the structures ss to w in the format of
+// "dmidecode --dump-bin", which "dmidecode --from-dump" and OpenDump read:
+// the entry point at offset 0, with its table address, length and
+// checksums updated, and the table at offset 32.
+//
+// A 32-bit or legacy DMI entry point can not describe a table over 65535
+// bytes or with more than 65535 structures, and an error is returned.
+//
+// Entry points other than EntryPoint32Bit, EntryPoint64Bit and
+// LegacyDMIEntryPoint, such as a WindowsEntryPoint, are written as a 32-bit
+// or 64-bit entry point depending on the SMBIOS version.
+func WriteDump(w io.Writer, ep EntryPoint, ss []*Structure) error {
+	table := encodeStructures(ss)
+
+	var (
+		epb []byte
+		err error
+	)
+	switch ep := ep.(type) {
+	case *EntryPoint32Bit:
+		epb, err = dumpEntryPoint32(ep, table, ss)
+	case *EntryPoint64Bit:
+		epb = dumpEntryPoint64(ep, table)
+	case *LegacyDMIEntryPoint:
+		epb, err = dumpEntryPointDMI(ep, table, ss)
+	default:
+		if ep == nil {
+			return fmt.Errorf("no entry point to write to dump")
+		}
+		major, minor, revision := ep.Version()
+		if major >= 3 {
+			epb = dumpEntryPoint64(&EntryPoint64Bit{
+				Major:              uint8(major),
+				Minor:              uint8(minor),
+				Revision:           uint8(revision),
+				EntryPointRevision: 1,
+			}, table)
+		} else {
+			epb, err = dumpEntryPoint32(&EntryPoint32Bit{
+				Major: uint8(major),
+				Minor: uint8(minor),
+			}, table, ss)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	b := make([]byte, dumpTableOffset, dumpTableOffset+len(table))
+	copy(b, epb)
+	b = append(b, table...)
+
+	_, err = w.Write(b)
+	return err
+}
+
+// encodeStructures encodes ss into a structure table.
+func encodeStructures(ss []*Structure) []byte {
+	var buf bytes.Buffer
+	for _, s := range ss {
+		buf.Write(encodeStructure(s))
+	}
+	return buf.Bytes()
+}
+
+// encodeStructure encodes s as it appears in a structure table.  The
+// length in the header is taken from the formatted area.
+func encodeStructure(s *Structure) []byte {
+	b := make([]byte, headerLen, headerLen+len(s.Formatted)+2)
+	b[0] = s.Header.Type
+	b[1] = uint8(headerLen + len(s.Formatted))
+	binary.LittleEndian.PutUint16(b[2:4], s.Header.Handle)
+	b = append(b, s.Formatted...)
+
+	if len(s.Strings) == 0 {
+		return append(b, endStringSet...)
+	}
+	for _, str := range s.Strings {
+		b = append(b, str...)
+		b = append(b, 0x00)
+	}
+	return append(b, 0x00)
+}
+
+// maxStructureSize returns the size of the largest encoded structure in ss.
+func maxStructureSize(ss []*Structure) uint16 {
+	var max int
+	for _, s := range ss {
+		if l := len(encodeStructure(s)); l > max {
+			max = l
+		}
+	}
+	return uint16(max)
+}
+
+// check16BitTable returns an error if table or ss do not fit in the 16-bit
+// length and count fields of a 32-bit or legacy DMI entry point.
+func check16BitTable(table []byte, ss []*Structure) error {
+	if l := len(table); l > 0xffff {
+		return fmt.Errorf("structure table of %d bytes is too large for a 32-bit entry point, maximum is %d", l, 0xffff)
+	}
+	if n := len(ss); n > 0xffff {
+		return fmt.Errorf("%d structures are too many for a 32-bit entry point, maximum is %d", n, 0xffff)
+	}
+	return nil
+}
+
+// dumpEntryPoint32 encodes ep as a dump entry point for table.
+func dumpEntryPoint32(ep *EntryPoint32Bit, table []byte, ss []*Structure) ([]byte, error) {
+	if err := check16BitTable(table, ss); err != nil {
+		return nil, err
+	}
+
+	const length = 0x1f
+	b := make([]byte, length)
+
+	copy(b[0:4], magic32)
+	b[5] = length
+	b[6] = ep.Major
+	b[7] = ep.Minor
+	binary.LittleEndian.PutUint16(b[8:10], maxStructureSize(ss))
+	copy(b[10:15], ep.FormattedArea[:])
+	b[10] = ep.EntryPointRevision
+
+	copy(b[16:21], magicDMI)
+	binary.LittleEndian.PutUint16(b[22:24], uint16(len(table)))
+	binary.LittleEndian.PutUint32(b[24:28], dumpTableOffset)
+	binary.LittleEndian.PutUint16(b[28:30], uint16(len(ss)))
+	b[30] = ep.BCDRevision
+	if b[30] == 0 && ep.Minor < 10 {
+		b[30] = ep.Major<<4 | ep.Minor
+	}
+
+	// The intermediate checksum covers the intermediate entry point only,
+	// the entry point checksum covers everything including it.
+	b[21] = checksumByte(b[16:31])
+	b[4] = checksumByte(b)
+
+	return b, nil
+}
+
+// dumpEntryPoint64 encodes ep as a dump entry point for table.
+func dumpEntryPoint64(ep *EntryPoint64Bit, table []byte) []byte {
+	b := make([]byte, expLen64)
+
+	copy(b[0:5], magic64)
+	b[6] = expLen64
+	b[7] = ep.Major
+	b[8] = ep.Minor
+	b[9] = ep.Revision
+	b[10] = ep.EntryPointRevision
+	binary.LittleEndian.PutUint32(b[12:16], uint32(len(table)))
+	binary.LittleEndian.PutUint64(b[16:24], dumpTableOffset)
+	b[chkIndex64] = checksumByte(b)
+
+	return b
+}
+
+// dumpEntryPointDMI encodes ep as a dump entry point for table.
+func dumpEntryPointDMI(ep *LegacyDMIEntryPoint, table []byte, ss []*Structure) ([]byte, error) {
+	if err := check16BitTable(table, ss); err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, expLenDMI)
+
+	copy(b[0:5], magicDMI)
+	binary.LittleEndian.PutUint16(b[6:8], uint16(len(table)))
+	binary.LittleEndian.PutUint32(b[8:12], dumpTableOffset)
+	binary.LittleEndian.PutUint16(b[12:14], uint16(len(ss)))
+	b[14] = ep.BCDRevision
+	b[chkIndexDMI] = checksumByte(b)
+
+	return b, nil
+}
+
+// checksumByte returns the value which, stored in the zero checksum byte
+// of b, makes the bytes of b sum to zero.
+func checksumByte(b []byte) uint8 {
+	var sum uint8
+	for _, v := range b {
+		sum += v
+	}
+	return -sum
+}
@@ -0,0 +1,245 @@
+package smbios_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestDumpRoundTrip(t *testing.T) {
+	ss := []*smbios.Structure{
+		{
+			Header:    smbios.Header{Type: 0, Length: 6, Handle: 0},
+			Formatted: []byte{0x01, 0x02},
+			Strings:   []string{"Dell Inc.", "2.10.2"},
+		},
+		{
+			Header:    smbios.Header{Type: 32, Length: 5, Handle: 1},
+			Formatted: []byte{0x00},
+		},
+		{
+			Header: smbios.Header{Type: 127, Length: 4, Handle: 2},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		ep       smbios.EntryPoint
+		addrOff  int
+		addrSize int
+		version  [3]int
+	}{
+		{
+			name:     "32-bit",
+			ep:       &smbios.EntryPoint32Bit{Major: 2, Minor: 8, EntryPointRevision: 0},
+			addrOff:  24,
+			addrSize: 4,
+			version:  [3]int{2, 8, 0},
+		},
+		{
+			name:     "64-bit",
+			ep:       &smbios.EntryPoint64Bit{Major: 3, Minor: 2, Revision: 1, EntryPointRevision: 1},
+			addrOff:  16,
+			addrSize: 8,
+			version:  [3]int{3, 2, 1},
+		},
+		{
+			name:     "legacy DMI",
+			ep:       &smbios.LegacyDMIEntryPoint{BCDRevision: 0x21},
+			addrOff:  8,
+			addrSize: 4,
+			version:  [3]int{2, 1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := smbios.WriteDump(&buf, tt.ep, ss); err != nil {
+				t.Fatalf("failed to write dump: %v", err)
+			}
+			b := buf.Bytes()
+
+			var addr uint64
+			if tt.addrSize == 4 {
+				addr = uint64(binary.LittleEndian.Uint32(b[tt.addrOff:]))
+			} else {
+				addr = binary.LittleEndian.Uint64(b[tt.addrOff:])
+			}
+			if addr != 32 {
+				t.Fatalf("unexpected table address: %#x", addr)
+			}
+
+			path := filepath.Join(t.TempDir(), "dump.bin")
+			if err := ioutil.WriteFile(path, b, 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			rc, ep, err := smbios.OpenDump(path)
+			if err != nil {
+				t.Fatalf("failed to open dump: %v", err)
+			}
+			defer rc.Close()
+
+			major, minor, revision := ep.Version()
+			if diff := cmp.Diff(tt.version, [3]int{major, minor, revision}); diff != "" {
+				t.Fatalf("unexpected version (-want +got):\n%s", diff)
+			}
+
+			table, err := ioutil.ReadAll(rc)
+			if err != nil {
+				t.Fatalf("failed to read table: %v", err)
+			}
+			if diff := cmp.Diff(b[32:], table); diff != "" {
+				t.Fatalf("unexpected table (-want +got):\n%s", diff)
+			}
+
+			got, err := smbios.NewDecoder(bytes.NewReader(table)).Decode()
+			if err != nil {
+				t.Fatalf("failed to decode table: %v", err)
+			}
+			if diff := cmp.Diff(ss, got); diff != "" {
+				t.Fatalf("unexpected structures (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOpenDumpTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	ep := &smbios.EntryPoint32Bit{Major: 2, Minor: 7}
+	ss := []*smbios.Structure{{Header: smbios.Header{Type: 127, Length: 4}}}
+	if err := smbios.WriteDump(&buf, ep, ss); err != nil {
+		t.Fatalf("failed to write dump: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "dump.bin")
+	if err := ioutil.WriteFile(path, buf.Bytes()[:33], 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if _, _, err := smbios.OpenDump(path); err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+}
+
+func TestWriteDumpTooLarge(t *testing.T) {
+	// 300 structures of 255 formatted bytes exceed the 16-bit table length.
+	big := make([]*smbios.Structure, 300)
+	for i := range big {
+		big[i] = &smbios.Structure{
+			Header:    smbios.Header{Type: 0x80, Handle: uint16(i)},
+			Formatted: make([]byte, 251),
+		}
+	}
+
+	tests := []struct {
+		name string
+		ep   smbios.EntryPoint
+		ss   []*smbios.Structure
+		ok   bool
+	}{
+		{
+			name: "32-bit, table too large",
+			ep:   &smbios.EntryPoint32Bit{Major: 2, Minor: 8},
+			ss:   big,
+		},
+		{
+			name: "legacy DMI, table too large",
+			ep:   &smbios.LegacyDMIEntryPoint{BCDRevision: 0x21},
+			ss:   big,
+		},
+		{
+			name: "64-bit, table too large",
+			ep:   &smbios.EntryPoint64Bit{Major: 3, Minor: 2},
+			ss:   big,
+			ok:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := smbios.WriteDump(ioutil.Discard, tt.ep, tt.ss)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
+
+func TestOpenDumpFixtures(t *testing.T) {
+	// The fixtures follow the layout of "dmidecode --dump-bin": the entry
+	// point with its table address rewritten to 32 and its checksums
+	// updated, zero padding, and the table of a QEMU i440FX guest at 32.
+	tests := []struct {
+		name    string
+		path    string
+		ep      smbios.EntryPoint
+		version [3]int
+	}{
+		{
+			name:    "SMBIOS 2.8",
+			path:    "testdata/dump/sm.bin",
+			ep:      &smbios.EntryPoint32Bit{},
+			version: [3]int{2, 8, 0},
+		},
+		{
+			name:    "SMBIOS3 3.0",
+			path:    "testdata/dump/sm3.bin",
+			ep:      &smbios.EntryPoint64Bit{},
+			version: [3]int{3, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, ep, err := smbios.OpenDump(tt.path)
+			if err != nil {
+				t.Fatalf("failed to open dump: %v", err)
+			}
+			defer rc.Close()
+
+			if want, got := fmt.Sprintf("%T", tt.ep), fmt.Sprintf("%T", ep); want != got {
+				t.Fatalf("unexpected entry point type: want %s, got %s", want, got)
+			}
+			major, minor, revision := ep.Version()
+			if diff := cmp.Diff(tt.version, [3]int{major, minor, revision}); diff != "" {
+				t.Fatalf("unexpected version (-want +got):\n%s", diff)
+			}
+			if addr, _ := ep.Table(); addr != 32 {
+				t.Fatalf("unexpected table address: %#x", addr)
+			}
+
+			ss, warnings, err := smbios.NewDecoder(rc).DecodeWith(smbios.EntryPointDecodeOptions(ep))
+			if err != nil {
+				t.Fatalf("failed to decode table: %v", err)
+			}
+			if len(warnings) != 0 {
+				t.Fatalf("unexpected warnings: %v", warnings)
+			}
+
+			var types []uint8
+			for _, s := range ss {
+				types = append(types, s.Header.Type)
+			}
+			if diff := cmp.Diff([]uint8{0, 1, 127}, types); diff != "" {
+				t.Fatalf("unexpected structure types (-want +got):\n%s", diff)
+			}
+
+			want := []string{"QEMU", "Standard PC (i440FX + PIIX, 1996)", "pc-i440fx-8.2"}
+			if diff := cmp.Diff(want, ss[1].Strings); diff != "" {
+				t.Fatalf("unexpected system strings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -62,6 +62,8 @@ func ParseEntryPoint(r io.Reader) (EntryPoint, error) {
 		return parse32(b)
 	case bytes.HasPrefix(b, magic64):
 		return parse64(b)
+	case bytes.HasPrefix(b, magicDMI):
+		return parseDMI(b)
 	}
 
 	return nil, fmt.Errorf("unrecognized SMBIOS entry point magic: %v", b[0:4])
@@ -202,10 +204,13 @@ func parse64(b []byte) (*EntryPoint64Bit, error) {
 	if l < int(length) {
 		return nil, fmt.Errorf("expected SMBIOS 64-bit entry point actual length of at least %d, but got: %d", length, l)
 	}
+	if int(length) < expLen64 {
+		return nil, fmt.Errorf("SMBIOS 64-bit entry point length %d is less than minimum length %d", length, expLen64)
+	}
 
 	// Checksum occurs at index 5, compute and verify it.
 	chk := b[chkIndex64]
-	if err := checksum(chk, chkIndex64, b); err != nil {
+	if err := checksum(chk, chkIndex64, b[:length]); err != nil {
 		return nil, err
 	}
 
@@ -223,6 +228,67 @@ func parse64(b []byte) (*EntryPoint64Bit, error) {
 	}, nil
 }
 
+var _ EntryPoint = &LegacyDMIEntryPoint{}
+
+// LegacyDMIEntryPoint is the standalone legacy DMI entry point, used by
+// systems predating SMBIOS 2.1.  It is the same as the intermediate entry
+// point of an EntryPoint32Bit.
+type LegacyDMIEntryPoint struct {
+	Anchor                string
+	Checksum              uint8
+	StructureTableLength  uint16
+	StructureTableAddress uint32
+	NumberStructures      uint16
+	BCDRevision           uint8
+}
+
+// Table implements EntryPoint.
+func (e *LegacyDMIEntryPoint) Table() (address, size int) {
+	return int(e.StructureTableAddress), int(e.StructureTableLength)
+}
+
+// Version implements EntryPoint.  The version is decoded from the BCD
+// revision, so 0x21 is version 2.1.
+func (e *LegacyDMIEntryPoint) Version() (major, minor, revision int) {
+	return int(e.BCDRevision >> 4), int(e.BCDRevision & 0x0f), 0
+}
+
+const (
+	// expLenDMI is the length of a legacy DMI entry point.
+	expLenDMI = 15
+
+	// chkIndexDMI is the index of the checksum byte in a legacy DMI entry
+	// point.
+	chkIndexDMI = 5
+)
+
+// parseDMI parses a LegacyDMIEntryPoint from b.
+func parseDMI(b []byte) (*LegacyDMIEntryPoint, error) {
+	l := len(b)
+
+	// Ensure expected length; more data may follow when the entry point
+	// is being read from system memory.
+	if l < expLenDMI {
+		return nil, fmt.Errorf("expected legacy DMI entry point length of at least %d, but got: %d", expLenDMI, l)
+	}
+	b = b[:expLenDMI]
+
+	// Checksum occurs at index 5, compute and verify it.
+	chk := b[chkIndexDMI]
+	if err := checksum(chk, chkIndexDMI, b); err != nil {
+		return nil, err
+	}
+
+	return &LegacyDMIEntryPoint{
+		Anchor:                string(b[0:5]),
+		Checksum:              chk,
+		StructureTableLength:  binary.LittleEndian.Uint16(b[6:8]),
+		StructureTableAddress: binary.LittleEndian.Uint32(b[8:12]),
+		NumberStructures:      binary.LittleEndian.Uint16(b[12:14]),
+		BCDRevision:           b[14],
+	}, nil
+}
+
 // checksum computes the checksum of b using the starting value of start, and
 // skipping the checksum byte which occurs at index chkIndex.
 //
@@ -175,6 +175,46 @@ func TestParseEntryPoint(t *testing.T) {
 			addr: 0x7af09000, size: 0x0f5f,
 			ok: true,
 		},
+		{
+			name: "legacy DMI, short entry point",
+			b: []byte{
+				'_', 'D', 'M', 'I', '_',
+			},
+		},
+		{
+			name: "legacy DMI, bad checksum",
+			b: []byte{
+				'_', 'D', 'M', 'I', '_',
+				0x00, // 0 checksum
+				0x23, 0x01,
+				0x00, 0x10, 0x0f, 0x00,
+				0x10, 0x00,
+				0x20,
+			},
+		},
+		{
+			name: "legacy DMI, OK, trailing data",
+			b: []byte{
+				'_', 'D', 'M', 'I', '_',
+				0xf5,
+				0x23, 0x01,
+				0x00, 0x10, 0x0f, 0x00,
+				0x10, 0x00,
+				0x20,
+				0xff,
+			},
+			ep: &smbios.LegacyDMIEntryPoint{
+				Anchor:                "_DMI_",
+				Checksum:              0xf5,
+				StructureTableLength:  0x0123,
+				StructureTableAddress: 0x000f1000,
+				NumberStructures:      0x0010,
+				BCDRevision:           0x20,
+			},
+			major: 2, minor: 0, revision: 0,
+			addr: 0x000f1000, size: 0x0123,
+			ok: true,
+		},
 		{
 			name: "64, short entry point",
 			b: []byte{
@@ -196,6 +236,21 @@ func TestParseEntryPoint(t *testing.T) {
 				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			},
 		},
+		{
+			name: "64, length below minimum",
+			b: []byte{
+				'_', 'S', 'M', '3', '_',
+				0x00,
+				0x10, // 16 length
+				0x00,
+				0x00,
+				0x00,
+				0x00,
+				0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
 		{
 			name: "64, bad checksum",
 			b: []byte{
@@ -266,6 +321,36 @@ func TestParseEntryPoint(t *testing.T) {
 			addr: 0x0eb3b0, size: 0x0953,
 			ok: true,
 		},
+		{
+			// An SMBIOS3 entry point read from a file or memory is followed by
+			// unrelated bytes, which are not part of its checksum.
+			name: "SMBIOS3 with trailing data",
+			b: []byte{
+				'_', 'S', 'M', '3', '_',
+				0x86,
+				0x18,
+				0x3,
+				0x0,
+				0x0,
+				0x1,
+				0x0,
+				0x53, 0x9, 0x0, 0x0,
+				0xb0, 0xb3, 0xe, 0x0, 0x0, 0x0, 0x0, 0x0,
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			},
+			ep: &smbios.EntryPoint64Bit{
+				Anchor:                "_SM3_",
+				Checksum:              0x86,
+				Length:                0x18,
+				Major:                 0x03,
+				EntryPointRevision:    0x01,
+				StructureTableMaxSize: 0x0953,
+				StructureTableAddress: 0x0eb3b0,
+			},
+			major: 3, minor: 0, revision: 0,
+			addr: 0x0eb3b0, size: 0x0953,
+			ok: true,
+		},
 	}
 
 	for _, tt := range tests {
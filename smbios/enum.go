@@ -0,0 +1,291 @@
+package smbios
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// An enum describes the names of the values of an enumerated SMBIOS field.
+type enum struct {
+	// typ is the Go type name, used to render values without a name.
+	typ   string
+	names map[uint16]string
+}
+
+// newEnum creates an enum from a slice of names, where names[i] is the name
+// of value first+i.
+func newEnum(typ string, first int, names []string) enum {
+	e := enum{typ: typ, names: make(map[uint16]string, len(names))}
+	for i, n := range names {
+		e.names[uint16(first+i)] = n
+	}
+	return e.unique()
+}
+
+// newEnumMap creates an enum from a map of values to names.
+func newEnumMap(typ string, names map[int]string) enum {
+	e := enum{typ: typ, names: make(map[uint16]string, len(names))}
+	for v, n := range names {
+		e.names[uint16(v)] = n
+	}
+	return e.unique()
+}
+
+// unique drops the names which do not identify a single value, such as the
+// "Reserved" entries of a table, so that format renders those values as
+// "Type(v)" and every formatted value parses back to itself.
+func (e enum) unique() enum {
+	values := make(map[string][]uint16, len(e.names))
+	for v, n := range e.names {
+		k := strings.TrimSpace(n)
+		values[k] = append(values[k], v)
+	}
+
+	for k, vs := range values {
+		if len(vs) == 1 && k != "" && !strings.EqualFold(k, "Reserved") {
+			continue
+		}
+		for _, v := range vs {
+			delete(e.names, v)
+		}
+	}
+	return e
+}
+
+// format returns the name of v.  Values without a name, such as reserved or
+// OEM-specific ones, are rendered as "Type(v)" so the number is not lost.
+func (e enum) format(v uint16) string {
+	if n, ok := e.names[v]; ok {
+		return n
+	}
+	return e.typ + "(" + strconv.Itoa(int(v)) + ")"
+}
+
+// parse is the inverse of format.  Names are matched exactly, or
+// case-insensitively when that identifies a single value.  Plain numbers are
+// accepted as well.
+func (e enum) parse(b []byte, max uint16) (uint16, error) {
+	s := strings.TrimSpace(string(b))
+
+	var folded []uint16
+	for v, n := range e.names {
+		if n == s {
+			return v, nil
+		}
+		if strings.EqualFold(n, s) {
+			folded = append(folded, v)
+		}
+	}
+	if len(folded) == 1 {
+		return folded[0], nil
+	}
+
+	num := strings.TrimSuffix(strings.TrimPrefix(s, e.typ+"("), ")")
+	n, err := strconv.ParseUint(num, 0, 16)
+	if err != nil || n > uint64(max) {
+		return 0, fmt.Errorf("invalid %s %q", e.typ, s)
+	}
+	return uint16(n), nil
+}
+
+// A ChassisType is the type of a system enclosure, 7.4.1.
+type ChassisType uint8
+
+var chassisTypes = newEnum("ChassisType", 0x01, Chassis_Type)
+
+// String implements fmt.Stringer.
+func (t ChassisType) String() string { return chassisTypes.format(uint16(t)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (t ChassisType) MarshalText() ([]byte, error) { return []byte(t.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *ChassisType) UnmarshalText(b []byte) error {
+	v, err := chassisTypes.parse(b, 0xFF)
+	*t = ChassisType(v)
+	return err
+}
+
+// A ChassisState is the boot-up, power supply or thermal state of a system
+// enclosure, 7.4.2.
+type ChassisState uint8
+
+var chassisStates = newEnum("ChassisState", 0x01, Chassis_State)
+
+// String implements fmt.Stringer.
+func (s ChassisState) String() string { return chassisStates.format(uint16(s)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ChassisState) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ChassisState) UnmarshalText(b []byte) error {
+	v, err := chassisStates.parse(b, 0xFF)
+	*s = ChassisState(v)
+	return err
+}
+
+// A ChassisSecurityStatus is the security status of a system enclosure,
+// 7.4.3.
+type ChassisSecurityStatus uint8
+
+var chassisSecurityStatuses = newEnum("ChassisSecurityStatus", 0x01, Chassis_Security_State)
+
+// String implements fmt.Stringer.
+func (s ChassisSecurityStatus) String() string { return chassisSecurityStatuses.format(uint16(s)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ChassisSecurityStatus) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ChassisSecurityStatus) UnmarshalText(b []byte) error {
+	v, err := chassisSecurityStatuses.parse(b, 0xFF)
+	*s = ChassisSecurityStatus(v)
+	return err
+}
+
+// A WakeUpType is the event that caused the system to power up, 7.2.2.
+type WakeUpType uint8
+
+var wakeUpTypes = newEnum("WakeUpType", 0x00, wakeUpType)
+
+// String implements fmt.Stringer.
+func (t WakeUpType) String() string { return wakeUpTypes.format(uint16(t)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (t WakeUpType) MarshalText() ([]byte, error) { return []byte(t.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *WakeUpType) UnmarshalText(b []byte) error {
+	v, err := wakeUpTypes.parse(b, 0xFF)
+	*t = WakeUpType(v)
+	return err
+}
+
+// A BoardType is the type of a baseboard, 7.3.2.
+type BoardType uint8
+
+var boardTypes = newEnum("BoardType", 0x01, bitypes[1:])
+
+// String implements fmt.Stringer.
+func (t BoardType) String() string { return boardTypes.format(uint16(t)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (t BoardType) MarshalText() ([]byte, error) { return []byte(t.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *BoardType) UnmarshalText(b []byte) error {
+	v, err := boardTypes.parse(b, 0xFF)
+	*t = BoardType(v)
+	return err
+}
+
+// A ProcessorType is the type of a processor, 7.5.1.
+type ProcessorType uint8
+
+var processorTypes = newEnum("ProcessorType", 0x01, Processor_Type)
+
+// String implements fmt.Stringer.
+func (t ProcessorType) String() string { return processorTypes.format(uint16(t)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (t ProcessorType) MarshalText() ([]byte, error) { return []byte(t.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *ProcessorType) UnmarshalText(b []byte) error {
+	v, err := processorTypes.parse(b, 0xFF)
+	*t = ProcessorType(v)
+	return err
+}
+
+// A ProcessorFamily is the family of a processor, 7.5.2.  It holds values of
+// both the 1-byte Processor Family and the 2-byte Processor Family 2 fields.
+type ProcessorFamily uint16
+
+var processorFamilies = newEnumMap("ProcessorFamily", Processor_Family)
+
+// String implements fmt.Stringer.
+func (f ProcessorFamily) String() string { return processorFamilies.format(uint16(f)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (f ProcessorFamily) MarshalText() ([]byte, error) { return []byte(f.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *ProcessorFamily) UnmarshalText(b []byte) error {
+	v, err := processorFamilies.parse(b, 0xFFFF)
+	*f = ProcessorFamily(v)
+	return err
+}
+
+// A ProcessorUpgrade is the socket or upgrade method of a processor, 7.5.5.
+type ProcessorUpgrade uint8
+
+var processorUpgrades = newEnum("ProcessorUpgrade", 0x01, Processor_Upgrade)
+
+// String implements fmt.Stringer.
+func (u ProcessorUpgrade) String() string { return processorUpgrades.format(uint16(u)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (u ProcessorUpgrade) MarshalText() ([]byte, error) { return []byte(u.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *ProcessorUpgrade) UnmarshalText(b []byte) error {
+	v, err := processorUpgrades.parse(b, 0xFF)
+	*u = ProcessorUpgrade(v)
+	return err
+}
+
+// A MemoryFormFactor is the form factor of a memory device, 7.18.1.
+type MemoryFormFactor uint8
+
+var memoryFormFactors = newEnum("MemoryFormFactor", 0x01, Memory_Device_Factor[1:])
+
+// String implements fmt.Stringer.
+func (f MemoryFormFactor) String() string { return memoryFormFactors.format(uint16(f)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (f MemoryFormFactor) MarshalText() ([]byte, error) { return []byte(f.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *MemoryFormFactor) UnmarshalText(b []byte) error {
+	v, err := memoryFormFactors.parse(b, 0xFF)
+	*f = MemoryFormFactor(v)
+	return err
+}
+
+// A MemoryType is the type of a memory device, 7.18.2.
+type MemoryType uint8
+
+var memoryTypes = newEnum("MemoryType", 0x01, Memory_Device_Type[1:])
+
+// String implements fmt.Stringer.
+func (t MemoryType) String() string { return memoryTypes.format(uint16(t)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (t MemoryType) MarshalText() ([]byte, error) { return []byte(t.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *MemoryType) UnmarshalText(b []byte) error {
+	v, err := memoryTypes.parse(b, 0xFF)
+	*t = MemoryType(v)
+	return err
+}
+
+// A MemoryTechnology is the technology of a memory device, 7.18.6.
+type MemoryTechnology uint8
+
+var memoryTechnologies = newEnum("MemoryTechnology", 0x01, Memory_Device_Technology[1:])
+
+// String implements fmt.Stringer.
+func (t MemoryTechnology) String() string { return memoryTechnologies.format(uint16(t)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (t MemoryTechnology) MarshalText() ([]byte, error) { return []byte(t.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *MemoryTechnology) UnmarshalText(b []byte) error {
+	v, err := memoryTechnologies.parse(b, 0xFF)
+	*t = MemoryTechnology(v)
+	return err
+}
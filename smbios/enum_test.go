@@ -0,0 +1,182 @@
+package smbios_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestEnumText(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface {
+			String() string
+			MarshalText() ([]byte, error)
+		}
+		s string
+	}{
+		{name: "chassis", v: smbios.ChassisType(0x17), s: "Rack Mount Chassis"},
+		{name: "chassis unknown", v: smbios.ChassisType(0x70), s: "ChassisType(112)"},
+		{name: "memory type", v: smbios.MemoryType(0x1A), s: "DDR4"},
+		{name: "form factor", v: smbios.MemoryFormFactor(0x09), s: "DIMM"},
+		{name: "processor family 2", v: smbios.ProcessorFamily(0x101), s: "ARMv8"},
+		{name: "processor family OEM", v: smbios.ProcessorFamily(0xFF00), s: "ProcessorFamily(65280)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.String(); got != tt.s {
+				t.Fatalf("unexpected string: want %q, got %q", tt.s, got)
+			}
+
+			b, err := tt.v.MarshalText()
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			if string(b) != tt.s {
+				t.Fatalf("unexpected text: want %q, got %q", tt.s, b)
+			}
+		})
+	}
+}
+
+func TestEnumJSONRoundTrip(t *testing.T) {
+	type dimm struct {
+		Type       smbios.MemoryType
+		FormFactor smbios.MemoryFormFactor
+		Chassis    smbios.ChassisType
+	}
+
+	in := dimm{Type: 0x1A, FormFactor: 0x0D, Chassis: 0x7E}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if want := `{"Type":"DDR4","FormFactor":"SODIMM","Chassis":"ChassisType(126)"}`; string(b) != want {
+		t.Fatalf("unexpected JSON:\n- want: %s\n-  got: %s", want, b)
+	}
+
+	var out dimm
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if out != in {
+		t.Fatalf("unexpected round trip: want %+v, got %+v", in, out)
+	}
+
+	var mt smbios.MemoryType
+	if err := mt.UnmarshalText([]byte("ddr3")); err != nil || mt != 0x18 {
+		t.Fatalf("unexpected case-insensitive parse: %v, %v", mt, err)
+	}
+	if err := mt.UnmarshalText([]byte("not a memory type")); err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+}
+
+func TestEnumTextRoundTrip(t *testing.T) {
+	type enum interface {
+		MarshalText() ([]byte, error)
+		UnmarshalText(b []byte) error
+	}
+
+	tests := []struct {
+		name string
+		max  int
+		// at sets the enum to v and returns it.
+		at func(v int) enum
+		// value returns the current value of the enum.
+		value func(e enum) int
+	}{
+		{
+			name:  "ChassisType",
+			max:   0xFF,
+			at:    func(v int) enum { e := smbios.ChassisType(v); return &e },
+			value: func(e enum) int { return int(*e.(*smbios.ChassisType)) },
+		},
+		{
+			name:  "ChassisState",
+			max:   0xFF,
+			at:    func(v int) enum { e := smbios.ChassisState(v); return &e },
+			value: func(e enum) int { return int(*e.(*smbios.ChassisState)) },
+		},
+		{
+			name:  "ChassisSecurityStatus",
+			max:   0xFF,
+			at:    func(v int) enum { e := smbios.ChassisSecurityStatus(v); return &e },
+			value: func(e enum) int { return int(*e.(*smbios.ChassisSecurityStatus)) },
+		},
+		{
+			name:  "WakeUpType",
+			max:   0xFF,
+			at:    func(v int) enum { e := smbios.WakeUpType(v); return &e },
+			value: func(e enum) int { return int(*e.(*smbios.WakeUpType)) },
+		},
+		{
+			name:  "BoardType",
+			max:   0xFF,
+			at:    func(v int) enum { e := smbios.BoardType(v); return &e },
+			value: func(e enum) int { return int(*e.(*smbios.BoardType)) },
+		},
+		{
+			name:  "ProcessorType",
+			max:   0xFF,
+			at:    func(v int) enum { e := smbios.ProcessorType(v); return &e },
+			value: func(e enum) int { return int(*e.(*smbios.ProcessorType)) },
+		},
+		{
+			name:  "ProcessorFamily",
+			max:   0xFFFF,
+			at:    func(v int) enum { e := smbios.ProcessorFamily(v); return &e },
+			value: func(e enum) int { return int(*e.(*smbios.ProcessorFamily)) },
+		},
+		{
+			name:  "ProcessorUpgrade",
+			max:   0xFF,
+			at:    func(v int) enum { e := smbios.ProcessorUpgrade(v); return &e },
+			value: func(e enum) int { return int(*e.(*smbios.ProcessorUpgrade)) },
+		},
+		{
+			name:  "MemoryFormFactor",
+			max:   0xFF,
+			at:    func(v int) enum { e := smbios.MemoryFormFactor(v); return &e },
+			value: func(e enum) int { return int(*e.(*smbios.MemoryFormFactor)) },
+		},
+		{
+			name:  "MemoryType",
+			max:   0xFF,
+			at:    func(v int) enum { e := smbios.MemoryType(v); return &e },
+			value: func(e enum) int { return int(*e.(*smbios.MemoryType)) },
+		},
+		{
+			name:  "MemoryTechnology",
+			max:   0xFF,
+			at:    func(v int) enum { e := smbios.MemoryTechnology(v); return &e },
+			value: func(e enum) int { return int(*e.(*smbios.MemoryTechnology)) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for v := 0; v <= tt.max; v++ {
+				b, err := tt.at(v).MarshalText()
+				if err != nil {
+					t.Fatalf("failed to marshal %d: %v", v, err)
+				}
+
+				e := tt.at(0)
+				if err := e.UnmarshalText(b); err != nil {
+					t.Fatalf("failed to unmarshal %d from %q: %v", v, b, err)
+				}
+				if got := tt.value(e); got != v {
+					t.Fatalf("%d marshaled to %q, which unmarshaled to %d", v, b, got)
+				}
+			}
+		})
+	}
+
+	if s := smbios.MemoryType(0x16).String(); s != "MemoryType(22)" {
+		t.Fatalf("unexpected reserved memory type: %q", s)
+	}
+}
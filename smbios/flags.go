@@ -0,0 +1,79 @@
+package smbios
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// A flagSet describes the names of the bits of a bit field SMBIOS field.
+type flagSet struct {
+	typ   string
+	names []string // names[i] is the name of bit i
+}
+
+// newFlagSet creates a flagSet of width bits from a slice of names, where
+// names[i] is the name of bit i.  Bits without a name, or named "Reserved",
+// are given a unique name so that they survive a round trip.
+func newFlagSet(typ string, width int, names []string) flagSet {
+	fs := flagSet{typ: typ, names: make([]string, width)}
+	for i := range fs.names {
+		if i < len(names) && names[i] != "" && names[i] != "Reserved" {
+			fs.names[i] = names[i]
+		} else {
+			fs.names[i] = fmt.Sprintf("Reserved (bit %d)", i)
+		}
+	}
+	return fs
+}
+
+// bits returns each bit set in v as its own value.
+func (fs flagSet) bits(v uint64) []uint64 {
+	var out []uint64
+	for i := range fs.names {
+		if b := uint64(1) << uint(i); v&b != 0 {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// strings returns the names of the bits set in v.
+func (fs flagSet) strings(v uint64) []string {
+	out := []string{}
+	for i, n := range fs.names {
+		if v&(1<<uint(i)) != 0 {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// marshalJSON encodes v as a JSON list of names.
+func (fs flagSet) marshalJSON(v uint64) ([]byte, error) {
+	return json.Marshal(fs.strings(v))
+}
+
+// unmarshalJSON decodes a JSON list of names, matched case-insensitively.
+func (fs flagSet) unmarshalJSON(b []byte) (uint64, error) {
+	var names []string
+	if err := json.Unmarshal(b, &names); err != nil {
+		return 0, err
+	}
+
+	var v uint64
+	for _, n := range names {
+		found := false
+		for i, fn := range fs.names {
+			if strings.EqualFold(fn, n) {
+				v |= 1 << uint(i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("invalid %s flag %q", fs.typ, n)
+		}
+	}
+	return v, nil
+}
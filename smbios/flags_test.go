@@ -0,0 +1,79 @@
+package smbios_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestBIOSCharacteristics(t *testing.T) {
+	s := &smbios.Structure{
+		Header: smbios.Header{Type: 0, Length: 0x18},
+		Formatted: []byte{
+			0x01, 0x02, 0x00, 0xF0, 0x03, 0xFF,
+			// PCI, upgradeable, BIOS vendor bit 32, system vendor bit 48.
+			0x80, 0x08, 0x00, 0x00, 0x01, 0x00, 0x01, 0x00,
+			// ACPI, UEFI, virtual machine.
+			0x01, 0x18,
+			0x01, 0x02, 0xFF, 0xFF,
+		},
+	}
+
+	bios, err := smbios.ParseBIOSInformation(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := bios.BIOSCharacteristics
+	if !c.Has(smbios.BIOSCharPCI|smbios.BIOSCharUpgradeable) || c.Has(smbios.BIOSCharISA) {
+		t.Fatalf("unexpected characteristics: %s", c)
+	}
+
+	ext := bios.BIOSCharacteristicsExtensionBytes
+	if !ext.Has(smbios.BIOSExtUEFI) || !ext.Has(smbios.BIOSExtVirtualMachine) || ext.Has(smbios.BIOSExtUSBLegacy) {
+		t.Fatalf("unexpected characteristics extension: %s", ext)
+	}
+
+	wantFlags := []smbios.BIOSCharacteristics{smbios.BIOSCharPCI, smbios.BIOSCharUpgradeable, 1 << 32, 1 << 48}
+	if diff := cmp.Diff(wantFlags, c.Flags()); diff != "" {
+		t.Fatalf("unexpected flags (-want +got):\n%s", diff)
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	want := `["PCI is supported","BIOS is upgradeable","BIOS vendor reserved (bit 32)","System vendor reserved (bit 48)"]`
+	if string(b) != want {
+		t.Fatalf("unexpected JSON:\n- want: %s\n-  got: %s", want, b)
+	}
+
+	var out smbios.BIOSCharacteristics
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if out != c {
+		t.Fatalf("unexpected round trip: want %#x, got %#x", uint64(c), uint64(out))
+	}
+}
+
+func TestBaseboardFeatures(t *testing.T) {
+	s := &smbios.Structure{
+		Header:    smbios.Header{Type: 2, Length: 0x0F},
+		Formatted: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x09, 0x06, 0x03, 0x00, 0x0A, 0x00},
+	}
+
+	bb, err := smbios.ParseBaseboardInformation(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bb.FeatureFlags.Has(smbios.BaseboardHostingBoard|smbios.BaseboardReplaceable) || bb.FeatureFlags.Has(smbios.BaseboardRemovable) {
+		t.Fatalf("unexpected features: %s", bb.FeatureFlags)
+	}
+	if bb.BoardType.String() != "Motherboard" {
+		t.Fatalf("unexpected board type: %s", bb.BoardType)
+	}
+}
@@ -0,0 +1,208 @@
+package smbios
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// A JEP106 is a JEDEC JEP106 manufacturer identification code.
+//
+// Only a subset of the JEP106 table is known to this package, see
+// jep106Manufacturers.  Codes outside it are still decoded but have no
+// name and are reported as unknown.
+type JEP106 struct {
+	// Bank is the 1-based bank number, i.e. the number of 7Fh continuation
+	// codes plus one.
+	Bank uint8
+	// ID is the identification code within the bank, including its odd
+	// parity bit.
+	ID uint8
+}
+
+// JEP106FromSMBIOS decodes a manufacturer ID word as stored in the SMBIOS
+// table: the low byte holds the number of continuation codes and the high
+// byte holds the identification code, both with odd parity in bit 7.
+// A zero word is returned as the zero JEP106, meaning unknown.
+func JEP106FromSMBIOS(v uint16) JEP106 {
+	if v == 0 {
+		return JEP106{}
+	}
+	return JEP106{Bank: uint8(v&0x7F) + 1, ID: uint8(v >> 8)}
+}
+
+// Valid reports whether j is a well-formed code.
+func (j JEP106) Valid() bool {
+	return j.Bank > 0 && j.ID != 0x7F && oddParity(j.ID)
+}
+
+// Name returns the manufacturer name of j, or "" if it is not in the
+// subset of the JEP106 table known to this package.
+func (j JEP106) Name() string {
+	for _, m := range jep106Manufacturers {
+		if m.code == j {
+			return m.name
+		}
+	}
+	return ""
+}
+
+// Known reports whether j is in the subset of the JEP106 table known to
+// this package.
+func (j JEP106) Known() bool {
+	return j.Name() != ""
+}
+
+// String returns the manufacturer name of j.  A well-formed code that is
+// not known is returned as "Unknown (Bank N, Hex 0xNN)", anything else as
+// "Unknown".
+func (j JEP106) String() string {
+	if n := j.Name(); n != "" {
+		return n
+	}
+	if !j.Valid() {
+		return "Unknown"
+	}
+	return fmt.Sprintf("Unknown (Bank %d, Hex 0x%02X)", j.Bank, j.ID)
+}
+
+// ParseJEP106 decodes a manufacturer code written as a hex string, as some
+// BIOSes do in the Manufacturer string of a memory device.  It accepts
+// continuation code form ("7F7F7F0B"), SMBIOS word order ("80CE000080CE")
+// and identifier-first order ("CE00000000000000").
+func ParseJEP106(s string) (JEP106, bool) {
+	s = strings.Join(strings.Fields(s), "")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) < 2 {
+		return JEP106{}, false
+	}
+
+	if b[0] == 0x7F {
+		n := 0
+		for n < len(b) && b[n] == 0x7F {
+			n++
+		}
+		if n == len(b) {
+			return JEP106{}, false
+		}
+		j := JEP106{Bank: uint8(n) + 1, ID: b[n]}
+		return j, j.Valid()
+	}
+
+	// The byte order is ambiguous, prefer the order that names a known
+	// manufacturer and fall back to SMBIOS word order.
+	smbiosOrder := JEP106{Bank: b[0]&0x7F + 1, ID: b[1]}
+	idFirst := JEP106{Bank: b[1]&0x7F + 1, ID: b[0]}
+	switch {
+	case smbiosOrder.Name() != "":
+		return smbiosOrder, true
+	case idFirst.Name() != "":
+		return idFirst, true
+	case oddParity(b[0]) && smbiosOrder.Valid():
+		return smbiosOrder, true
+	}
+	return JEP106{}, false
+}
+
+// NormalizeManufacturer returns the canonical manufacturer name for a
+// Manufacturer string.  Hex codes and known spellings are mapped to the
+// name from the JEP106 table; anything else is returned trimmed.
+func NormalizeManufacturer(s string) string {
+	s = strings.TrimSpace(s)
+	if j, ok := ParseJEP106(s); ok {
+		if n := j.Name(); n != "" {
+			return n
+		}
+	}
+
+	l := strings.ToLower(s)
+	for _, m := range jep106Manufacturers {
+		for _, a := range m.aliases {
+			if strings.HasPrefix(l, a) {
+				return m.name
+			}
+		}
+	}
+	return s
+}
+
+// ModuleManufacturer returns the canonical name of the memory module
+// manufacturer, preferring a known Module Manufacturer ID over the
+// Manufacturer string.  Without either it returns the ID as JEP106.String
+// does.
+func (d *MemoryDeviceStructure) ModuleManufacturer() string {
+	j := JEP106FromSMBIOS(d.ModuleManufacturerID)
+	if j.Known() {
+		return j.Name()
+	}
+	if n := NormalizeManufacturer(d.Manufacturer); n != "" {
+		return n
+	}
+	return j.String()
+}
+
+// MemorySubsystemControllerManufacturer returns the canonical name of the
+// memory subsystem controller manufacturer, formatted as JEP106.String.
+func (d *MemoryDeviceStructure) MemorySubsystemControllerManufacturer() string {
+	return JEP106FromSMBIOS(d.MemorySubsystemControllerManufacturerID).String()
+}
+
+// PMIC0Manufacturer returns the canonical name of the PMIC0 manufacturer,
+// formatted as JEP106.String.
+func (d *MemoryDeviceStructure) PMIC0Manufacturer() string {
+	return JEP106FromSMBIOS(d.PMIC0ManufacturerID).String()
+}
+
+// RCDManufacturer returns the canonical name of the RCD manufacturer,
+// formatted as JEP106.String.
+func (d *MemoryDeviceStructure) RCDManufacturer() string {
+	return JEP106FromSMBIOS(d.RCDManufacturerID).String()
+}
+
+// oddParity reports whether b has an odd number of bits set.
+func oddParity(b uint8) bool {
+	b ^= b >> 4
+	b ^= b >> 2
+	b ^= b >> 1
+	return b&1 == 1
+}
+
+// jep106Manufacturers is a hand-maintained subset of JEP106 with the
+// manufacturers commonly found on memory modules and the RCD and PMIC
+// vendors Renesas (IDT), Montage and Rambus, not the full table.  Codes
+// missing here are reported as unknown rather than guessed.  aliases are lower case prefixes of
+// Manufacturer strings that name the same company.
+var jep106Manufacturers = []struct {
+	code    JEP106
+	name    string
+	aliases []string
+}{
+	{JEP106{1, 0x01}, "AMD", nil},
+	{JEP106{1, 0x04}, "Fujitsu", []string{"fujitsu"}},
+	{JEP106{1, 0x10}, "NEC", nil},
+	{JEP106{1, 0x1C}, "Mitsubishi", []string{"mitsubishi"}},
+	{JEP106{1, 0x2C}, "Micron Technology", []string{"micron"}},
+	{JEP106{1, 0x89}, "Intel", []string{"intel"}},
+	{JEP106{1, 0x97}, "Texas Instruments", []string{"texas instruments"}},
+	{JEP106{1, 0x98}, "Kioxia", []string{"kioxia", "toshiba"}},
+	{JEP106{1, 0xAD}, "SK Hynix", []string{"sk hynix", "hynix", "hyundai"}},
+	{JEP106{1, 0xB3}, "Renesas (IDT)", []string{"renesas", "idt", "integrated device technology"}},
+	{JEP106{1, 0xC1}, "Infineon", []string{"infineon"}},
+	{JEP106{1, 0xCE}, "Samsung", []string{"samsung"}},
+	{JEP106{2, 0x4F}, "Transcend", []string{"transcend"}},
+	{JEP106{2, 0x7A}, "Apacer", []string{"apacer"}},
+	{JEP106{2, 0x94}, "Smart Modular", []string{"smart modular"}},
+	{JEP106{2, 0x98}, "Kingston", []string{"kingston"}},
+	{JEP106{3, 0x16}, "Netlist", []string{"netlist"}},
+	{JEP106{3, 0x9E}, "Corsair", []string{"corsair"}},
+	{JEP106{3, 0xFE}, "Elpida", []string{"elpida"}},
+	{JEP106{4, 0x0B}, "Nanya Technology", []string{"nanya"}},
+	{JEP106{5, 0x32}, "Montage Technology", []string{"montage"}},
+	{JEP106{5, 0x43}, "Ramaxel Technology", []string{"ramaxel"}},
+	{JEP106{5, 0x9D}, "Rambus", []string{"rambus"}},
+	{JEP106{5, 0xCB}, "ADATA Technology", []string{"adata", "a-data"}},
+	{JEP106{5, 0xCD}, "G.Skill", []string{"g.skill", "g skill", "gskill"}},
+	{JEP106{5, 0xEF}, "Team Group", []string{"team group", "teamgroup"}},
+	{JEP106{6, 0x51}, "Qimonda", []string{"qimonda"}},
+}
@@ -0,0 +1,99 @@
+package smbios_test
+
+import (
+	"testing"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestJEP106FromSMBIOS(t *testing.T) {
+	tests := []struct {
+		v    uint16
+		want string
+	}{
+		{v: 0x0000, want: "Unknown"},
+		{v: 0xCE00, want: "Samsung"},
+		{v: 0x2C80, want: "Micron Technology"},
+		{v: 0xAD80, want: "SK Hynix"},
+		{v: 0x3204, want: "Montage Technology"},
+		{v: 0x9D04, want: "Rambus"},
+		{v: 0xB380, want: "Renesas (IDT)"},
+		{v: 0x0B83, want: "Nanya Technology"},
+		{v: 0x9E82, want: "Corsair"},
+		{v: 0x4F81, want: "Transcend"},
+		{v: 0x5D88, want: "Unknown (Bank 9, Hex 0x5D)"},
+		{v: 0xDD88, want: "Unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := smbios.JEP106FromSMBIOS(tt.v).String(); got != tt.want {
+			t.Errorf("JEP106FromSMBIOS(%#04x): want %q, got %q", tt.v, tt.want, got)
+		}
+	}
+}
+
+func TestNormalizeManufacturer(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "80CE000080CE", want: "Samsung"},
+		{s: "CE00000000000000", want: "Samsung"},
+		{s: "802C", want: "Micron Technology"},
+		{s: "7F7F7F0B", want: "Nanya Technology"},
+		{s: "SAMSUNG ", want: "Samsung"},
+		{s: "Hynix Semiconductor", want: "SK Hynix"},
+		{s: "Kingston", want: "Kingston"},
+		{s: "Renesas Electronics", want: "Renesas (IDT)"},
+		{s: "Not Specified", want: "Not Specified"},
+		{s: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := smbios.NormalizeManufacturer(tt.s); got != tt.want {
+			t.Errorf("NormalizeManufacturer(%q): want %q, got %q", tt.s, tt.want, got)
+		}
+	}
+}
+
+func TestMemoryDeviceManufacturers(t *testing.T) {
+	d := &smbios.MemoryDeviceStructure{
+		Manufacturer:         "00AD00B300AD",
+		ModuleManufacturerID: 0x2C80,
+		RCDManufacturerID:    0x3204,
+	}
+
+	if got := d.ModuleManufacturer(); got != "Micron Technology" {
+		t.Fatalf("unexpected module manufacturer: %q", got)
+	}
+	if got := d.RCDManufacturer(); got != "Montage Technology" {
+		t.Fatalf("unexpected RCD manufacturer: %q", got)
+	}
+	if got := d.PMIC0Manufacturer(); got != "Unknown" {
+		t.Fatalf("unexpected PMIC0 manufacturer: %q", got)
+	}
+
+	d.PMIC0ManufacturerID = 0x5D88
+	if got := d.PMIC0Manufacturer(); got != "Unknown (Bank 9, Hex 0x5D)" {
+		t.Fatalf("unexpected unknown PMIC0 manufacturer: %q", got)
+	}
+
+	d.ModuleManufacturerID = 0
+	if got := d.ModuleManufacturer(); got != "SK Hynix" {
+		t.Fatalf("unexpected module manufacturer from string: %q", got)
+	}
+
+	d.Manufacturer = ""
+	if got := d.ModuleManufacturer(); got != "Unknown" {
+		t.Fatalf("unexpected module manufacturer without ID or string: %q", got)
+	}
+}
+
+func TestJEP106Known(t *testing.T) {
+	if !smbios.JEP106FromSMBIOS(0xCE00).Known() {
+		t.Fatal("Samsung is not known")
+	}
+	if smbios.JEP106FromSMBIOS(0x5D88).Known() {
+		t.Fatal("Bank 9, Hex 0x5D is known")
+	}
+}
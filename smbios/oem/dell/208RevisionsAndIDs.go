@@ -0,0 +1,36 @@
+package dell
+
+import (
+	"encoding/binary"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// systemIDExtended 表示 System ID 放不下一个字节，真正的值在扩展字段中
+const systemIDExtended = 0xFE
+
+// RevisionsAndIDs Dell 的版本和 ID 记录，主要用来获取 System ID（平台 ID）
+type RevisionsAndIDs struct { // type 208
+	Handle uint16
+	// SystemID 是 Dell 的平台 ID，例如 R740 是 0x0715。6 字节处为 FEh 时
+	// 取 8-9 的扩展值
+	SystemID uint16 // 6 / 8-9
+}
+
+// ParseRevisionsAndIDs 解析structure
+func ParseRevisionsAndIDs(s *smbios.Structure) (*RevisionsAndIDs, error) {
+	if err := oemutil.CheckStructure(s, 3, TypeRevisionsAndIDs); err != nil {
+		return nil, err
+	}
+
+	ret := &RevisionsAndIDs{
+		Handle:   s.Header.Handle,
+		SystemID: uint16(s.Formatted[2]),
+	}
+	if ret.SystemID == systemIDExtended && len(s.Formatted) >= 6 {
+		ret.SystemID = binary.LittleEndian.Uint16(s.Formatted[4:6])
+	}
+
+	return ret, nil
+}
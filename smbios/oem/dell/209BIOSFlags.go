@@ -0,0 +1,50 @@
+package dell
+
+import (
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// BIOSFlags BIOS 标志位，type 209 和 210 格式相同，formatted 区域整个是一个小端
+// 位图，每一位表示 BIOS 的一项设置或能力。Dell 没有公开每一位的含义，随平台
+// 变化，所以按位号访问
+type BIOSFlags struct { // type 209, 210
+	Type   uint8
+	Handle uint16
+	Flags  []byte // 4 起的位图
+}
+
+// ParseBIOSFlags 解析structure
+func ParseBIOSFlags(s *smbios.Structure) (*BIOSFlags, error) {
+	if err := oemutil.CheckStructure(s, 1, TypeBIOSFlags, TypeBIOSFlags2); err != nil {
+		return nil, err
+	}
+
+	ret := &BIOSFlags{
+		Type:   s.Header.Type,
+		Handle: s.Header.Handle,
+		Flags:  make([]byte, len(s.Formatted)),
+	}
+	copy(ret.Flags, s.Formatted)
+
+	return ret, nil
+}
+
+// Bit 返回第 n 位（从 0 开始）是否置位
+func (f *BIOSFlags) Bit(n int) bool {
+	if n < 0 || n/8 >= len(f.Flags) {
+		return false
+	}
+	return f.Flags[n/8]&(1<<uint(n%8)) != 0
+}
+
+// Set 返回所有置位的位号，从小到大排列
+func (f *BIOSFlags) Set() []int {
+	var out []int
+	for n := 0; n < 8*len(f.Flags); n++ {
+		if f.Bit(n) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+package dell
+
+import (
+	"encoding/binary"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// tokenEnd 表示 token 表结束
+const tokenEnd = 0xFFFF
+
+// IndexedIOTokens 通过 CMOS 索引/数据端口读写的 token 表
+type IndexedIOTokens struct { // type 212
+	Handle     uint16
+	IndexPort  uint16            // 4-5
+	DataPort   uint16            // 6-7
+	CheckType  uint8             // 8
+	CheckStart uint8             // 9 校验范围起始索引
+	CheckEnd   uint8             // 10 校验范围结束索引
+	CheckIndex uint8             // 11 校验值所在的索引
+	Tokens     []*IndexedIOToken // 12 每个 5 字节，以 FFFFh 结束
+}
+
+// IndexedIOToken 一个 CMOS token
+type IndexedIOToken struct {
+	ID       uint16 // 0-1
+	Location uint8  // 2 CMOS 索引
+	AndMask  uint8  // 3
+	OrValue  uint8  // 4
+}
+
+// ParseIndexedIOTokens 解析structure
+func ParseIndexedIOTokens(s *smbios.Structure) (*IndexedIOTokens, error) {
+	if err := oemutil.CheckStructure(s, 8, TypeIndexedIOTokens); err != nil {
+		return nil, err
+	}
+
+	f := s.Formatted
+	ret := &IndexedIOTokens{
+		Handle:     s.Header.Handle,
+		IndexPort:  binary.LittleEndian.Uint16(f[0:2]),
+		DataPort:   binary.LittleEndian.Uint16(f[2:4]),
+		CheckType:  f[4],
+		CheckStart: f[5],
+		CheckEnd:   f[6],
+		CheckIndex: f[7],
+	}
+
+	for b := f[8:]; len(b) >= 5; b = b[5:] {
+		id := binary.LittleEndian.Uint16(b[0:2])
+		if id == tokenEnd {
+			break
+		}
+		ret.Tokens = append(ret.Tokens, &IndexedIOToken{
+			ID:       id,
+			Location: b[2],
+			AndMask:  b[3],
+			OrValue:  b[4],
+		})
+	}
+
+	return ret, nil
+}
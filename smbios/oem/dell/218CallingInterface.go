@@ -0,0 +1,53 @@
+package dell
+
+import (
+	"encoding/binary"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// CallingInterface SMI 调用接口以及通过它访问的 token 表
+type CallingInterface struct { // type 218
+	Handle            uint16
+	CommandIOAddress  uint16                   // 4-5
+	CommandIOCode     uint8                    // 6
+	SupportedCommands uint32                   // 7-10 按位表示支持的命令类
+	Tokens            []*CallingInterfaceToken // 11 每个 6 字节
+}
+
+// CallingInterfaceToken 一个 SMI token
+type CallingInterfaceToken struct {
+	ID       uint16 // 0-1
+	Location uint16 // 2-3
+	Value    uint16 // 4-5 字符串 token 时表示字符串长度
+}
+
+// ParseCallingInterface 解析structure
+func ParseCallingInterface(s *smbios.Structure) (*CallingInterface, error) {
+	if err := oemutil.CheckStructure(s, 7, TypeCallingInterface); err != nil {
+		return nil, err
+	}
+
+	f := s.Formatted
+	ret := &CallingInterface{
+		Handle:            s.Header.Handle,
+		CommandIOAddress:  binary.LittleEndian.Uint16(f[0:2]),
+		CommandIOCode:     f[2],
+		SupportedCommands: binary.LittleEndian.Uint32(f[3:7]),
+	}
+
+	for b := f[7:]; len(b) >= 6; b = b[6:] {
+		id := binary.LittleEndian.Uint16(b[0:2])
+		if id == tokenEnd {
+			break
+		}
+		ret.Tokens = append(ret.Tokens, &CallingInterfaceToken{
+			ID:       id,
+			Location: binary.LittleEndian.Uint16(b[2:4]),
+			Value:    binary.LittleEndian.Uint16(b[4:6]),
+		})
+	}
+
+	return ret, nil
+}
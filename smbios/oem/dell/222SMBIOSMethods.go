@@ -0,0 +1,22 @@
+package dell
+
+import (
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// SMBIOSMethods type 222 记录。它的存在表示 BIOS 提供 Dell SMBIOS 方法，也就是
+// 可以通过 type 218 的调用接口发起 SMI 调用（fwupd 等工具用它判断能否通过 BIOS
+// 更新固件）。记录内容没有公开文档，所以只记录它的存在
+type SMBIOSMethods struct { // type 222
+	Handle uint16
+}
+
+// ParseSMBIOSMethods 解析structure
+func ParseSMBIOSMethods(s *smbios.Structure) (*SMBIOSMethods, error) {
+	if err := oemutil.CheckStructure(s, 0, TypeSMBIOSMethods); err != nil {
+		return nil, err
+	}
+
+	return &SMBIOSMethods{Handle: s.Header.Handle}, nil
+}
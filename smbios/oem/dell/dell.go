@@ -0,0 +1,104 @@
+// Package dell 解析 Dell PowerEdge 服务器和 Dell 客户机 BIOS 写入的 OEM structure：
+// type 208 的平台 ID、209/210 的 BIOS 标志位、212 的 CMOS token 表、218 的 SMI
+// 调用接口和 222 的 SMBIOS 方法标记。
+//
+// 服务编号（Service Tag）来自 type 1 的序列号，ExpressServiceCode 把它换算成
+// 电话支持使用的快速服务代码。这些类型号只在 Dell 的机器上有上述含义，所以
+// 调用方需要先用 Detect 确认后再调用 Register。
+package dell
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+// Dell OEM structure 类型
+const (
+	TypeRevisionsAndIDs  = 208 // 0xD0
+	TypeBIOSFlags        = 209 // 0xD1
+	TypeBIOSFlags2       = 210 // 0xD2
+	TypeIndexedIOTokens  = 212 // 0xD4
+	TypeCallingInterface = 218 // 0xDA
+	TypeSMBIOSMethods    = 222 // 0xDE
+)
+
+// Records 保存从一张 SMBIOS 表中解析出来的 Dell OEM 记录
+type Records struct {
+	RevisionsAndIDs   *RevisionsAndIDs    // type 208
+	IndexedIOTokens   []*IndexedIOTokens  // type 212
+	CallingInterfaces []*CallingInterface // type 218
+	BIOSFlags         []*BIOSFlags        // type 209, 210
+	SMBIOSMethods     *SMBIOSMethods      // type 222
+	// ServiceTag 来自 type 1 的序列号
+	ServiceTag string
+}
+
+// Detect 根据 type 1 的厂商名判断是否是 Dell 的机器
+func Detect(ss []*smbios.Structure) bool {
+	for _, s := range ss {
+		if s.Header.Type == 1 {
+			return strings.HasPrefix(strings.ToUpper(s.StringAt(0)), "DELL")
+		}
+	}
+	return false
+}
+
+// Parse 解析 ss 中所有已知的 Dell OEM 记录，其他 structure 会被忽略。
+// 格式不正确的记录会被跳过，原因通过 errs 返回，不影响其他记录。
+func Parse(ss []*smbios.Structure) (ret *Records, errs []error) {
+	ret = &Records{}
+	for _, s := range ss {
+		var err error
+		switch s.Header.Type {
+		case 1:
+			ret.ServiceTag = s.StringAt(3)
+		case TypeRevisionsAndIDs:
+			var out *RevisionsAndIDs
+			if out, err = ParseRevisionsAndIDs(s); err == nil {
+				ret.RevisionsAndIDs = out
+			}
+		case TypeIndexedIOTokens:
+			var out *IndexedIOTokens
+			if out, err = ParseIndexedIOTokens(s); err == nil {
+				ret.IndexedIOTokens = append(ret.IndexedIOTokens, out)
+			}
+		case TypeCallingInterface:
+			var out *CallingInterface
+			if out, err = ParseCallingInterface(s); err == nil {
+				ret.CallingInterfaces = append(ret.CallingInterfaces, out)
+			}
+		case TypeBIOSFlags, TypeBIOSFlags2:
+			var out *BIOSFlags
+			if out, err = ParseBIOSFlags(s); err == nil {
+				ret.BIOSFlags = append(ret.BIOSFlags, out)
+			}
+		case TypeSMBIOSMethods:
+			var out *SMBIOSMethods
+			if out, err = ParseSMBIOSMethods(s); err == nil {
+				ret.SMBIOSMethods = out
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("handle %#04x: %v", s.Header.Handle, err))
+		}
+	}
+	return ret, errs
+}
+
+// SMBIOSMethodsAvailable 返回是否可以通过 SMI 调用 Dell SMBIOS 方法，需要同时有
+// type 222 标记和 type 218 调用接口
+func (r *Records) SMBIOSMethodsAvailable() bool {
+	return r.SMBIOSMethods != nil && len(r.CallingInterfaces) > 0
+}
+
+// Register 把本包的解析器注册到 smbios 的解析器注册表中
+func Register() {
+	smbios.RegisterParser(TypeRevisionsAndIDs, func(s *smbios.Structure) (interface{}, error) { return ParseRevisionsAndIDs(s) })
+	smbios.RegisterParser(TypeIndexedIOTokens, func(s *smbios.Structure) (interface{}, error) { return ParseIndexedIOTokens(s) })
+	smbios.RegisterParser(TypeCallingInterface, func(s *smbios.Structure) (interface{}, error) { return ParseCallingInterface(s) })
+	smbios.RegisterParser(TypeBIOSFlags, func(s *smbios.Structure) (interface{}, error) { return ParseBIOSFlags(s) })
+	smbios.RegisterParser(TypeBIOSFlags2, func(s *smbios.Structure) (interface{}, error) { return ParseBIOSFlags(s) })
+	smbios.RegisterParser(TypeSMBIOSMethods, func(s *smbios.Structure) (interface{}, error) { return ParseSMBIOSMethods(s) })
+}
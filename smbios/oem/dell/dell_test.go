@@ -0,0 +1,129 @@
+package dell_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/dell"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+func TestParse(t *testing.T) {
+	sys := make([]byte, 24)
+	sys[0], sys[3] = 0x01, 0x02
+
+	b := oemutil.MarshalTable(
+		oemutil.MarshalStructure(1, 0x0100, sys, "Dell Inc.", "1ABCD23"),
+		oemutil.MarshalStructure(208, 0x0D00, []byte{0x02, 0x00, 0xFE, 0x00, 0x15, 0x07}),
+		oemutil.MarshalStructure(209, 0x0D01, []byte{0x05, 0x80}),
+		oemutil.MarshalStructure(210, 0x0D04, []byte{0x01}),
+		oemutil.MarshalStructure(212, 0x0D02, []byte{
+			0x70, 0x00, 0x71, 0x00, 0x01, 0x10, 0x7F, 0x80,
+			0x5C, 0x00, 0x4E, 0xFE, 0x01,
+			0x5D, 0x00, 0x4E, 0xFE, 0x00,
+			0xFF, 0xFF, 0x00, 0x00, 0x00,
+		}),
+		oemutil.MarshalStructure(218, 0x0D03, []byte{
+			0xB2, 0x00, 0xDA, 0x0F, 0x00, 0x00, 0x00,
+			0x7A, 0x00, 0x10, 0x00, 0x01, 0x00,
+			0xFF, 0xFF, 0x00, 0x00, 0x00, 0x00,
+		}),
+		oemutil.MarshalStructure(222, 0x0D05, []byte{0x01, 0x00}),
+		oemutil.MarshalStructure(127, 0xFEFF, nil),
+	)
+
+	ss, err := smbios.NewDecoder(bytes.NewReader(b)).Decode()
+	if err != nil {
+		t.Fatalf("failed to decode synthetic table: %v", err)
+	}
+
+	if !dell.Detect(ss) {
+		t.Fatal("expected synthetic table to be detected as Dell")
+	}
+
+	got, errs := dell.Parse(ss)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	want := &dell.Records{
+		RevisionsAndIDs: &dell.RevisionsAndIDs{Handle: 0x0D00, SystemID: 0x0715},
+		IndexedIOTokens: []*dell.IndexedIOTokens{{
+			Handle:     0x0D02,
+			IndexPort:  0x70,
+			DataPort:   0x71,
+			CheckType:  0x01,
+			CheckStart: 0x10,
+			CheckEnd:   0x7F,
+			CheckIndex: 0x80,
+			Tokens: []*dell.IndexedIOToken{
+				{ID: 0x005C, Location: 0x4E, AndMask: 0xFE, OrValue: 0x01},
+				{ID: 0x005D, Location: 0x4E, AndMask: 0xFE, OrValue: 0x00},
+			},
+		}},
+		CallingInterfaces: []*dell.CallingInterface{{
+			Handle:            0x0D03,
+			CommandIOAddress:  0xB2,
+			CommandIOCode:     0xDA,
+			SupportedCommands: 0x0F,
+			Tokens: []*dell.CallingInterfaceToken{
+				{ID: 0x007A, Location: 0x0010, Value: 0x0001},
+			},
+		}},
+		BIOSFlags: []*dell.BIOSFlags{
+			{Type: 209, Handle: 0x0D01, Flags: []byte{0x05, 0x80}},
+			{Type: 210, Handle: 0x0D04, Flags: []byte{0x01}},
+		},
+		SMBIOSMethods: &dell.SMBIOSMethods{Handle: 0x0D05},
+		ServiceTag:    "1ABCD23",
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected records (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]int{0, 2, 15}, got.BIOSFlags[0].Set()); diff != "" {
+		t.Fatalf("unexpected BIOS flag bits (-want +got):\n%s", diff)
+	}
+	if f := got.BIOSFlags[0]; !f.Bit(2) || f.Bit(1) || f.Bit(16) {
+		t.Fatalf("unexpected BIOS flag bits: %#v", f.Flags)
+	}
+
+	if !got.SMBIOSMethodsAvailable() {
+		t.Fatal("expected Dell SMBIOS methods to be available")
+	}
+
+	esc, err := dell.ExpressServiceCode(got.ServiceTag)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "2800496667"; esc != want {
+		t.Fatalf("unexpected express service code: want %q, got %q", want, esc)
+	}
+}
+
+func TestParseSkipsBadRecords(t *testing.T) {
+	ss := []*smbios.Structure{
+		{
+			Header:    smbios.Header{Type: dell.TypeRevisionsAndIDs, Length: 5, Handle: 0x0D00},
+			Formatted: []byte{0x02},
+		},
+		{
+			Header:    smbios.Header{Type: dell.TypeBIOSFlags, Length: 5, Handle: 0x0D01},
+			Formatted: []byte{0x01},
+		},
+	}
+
+	got, errs := dell.Parse(ss)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, but got: %v", errs)
+	}
+	if got.RevisionsAndIDs != nil || len(got.BIOSFlags) != 1 {
+		t.Fatalf("unexpected records: %+v", got)
+	}
+	if got.SMBIOSMethodsAvailable() {
+		t.Fatal("expected Dell SMBIOS methods to be unavailable")
+	}
+}
@@ -0,0 +1,21 @@
+package dell
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExpressServiceCode 把 Dell 的服务标签（36 进制）转换成快速服务代码（10 进制）
+func ExpressServiceCode(tag string) (string, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return "", fmt.Errorf("empty service tag")
+	}
+
+	n, err := strconv.ParseUint(tag, 36, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid service tag %q: %v", tag, err)
+	}
+	return strconv.FormatUint(n, 10), nil
+}
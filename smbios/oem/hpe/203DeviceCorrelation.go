@@ -0,0 +1,124 @@
+package hpe
+
+import (
+	"encoding/binary"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// DeviceCorrelation 把 PCIe 设备和槽位（type 9）、UEFI 设备路径以及 iLO/Redfish
+// 使用的结构化名称关联起来
+type DeviceCorrelation struct { // type 203
+	Handle                 uint16
+	AssociatedDeviceHandle uint16 // 4-5 type 9, FFFEh 表示没有
+	AssociatedSMBusHandle  uint16 // 6-7 type 228, FFFEh 表示没有
+	PCIVendorID            uint16 // 8-9
+	PCIDeviceID            uint16 // 10-11
+	PCISubVendorID         uint16 // 12-13
+	PCISubDeviceID         uint16 // 14-15
+	ClassCode              uint8  // 16
+	SubClassCode           uint8  // 17
+	ParentHandle           uint16 // 18-19
+	Flags                  uint16 // 20-21
+	DeviceType             string // 22
+	DeviceLocation         string // 23
+	DeviceInstance         uint8  // 24
+	DeviceSubInstance      uint8  // 25
+	Bay                    uint8  // 26
+	Enclosure              uint8  // 27
+	UEFIDevicePath         string // 28 String number
+	StructuredName         string // 29 String number, Redfish 中使用的设备名
+	DeviceName             string // 30 String number
+	UEFILocation           string // 31 String number
+	Segment                uint16 // 32-33 可选
+	Bus                    uint8  // 34 可选
+	DeviceFunction         uint8  // 35 可选
+}
+
+// ParseDeviceCorrelation 解析structure
+func ParseDeviceCorrelation(s *smbios.Structure) (*DeviceCorrelation, error) {
+	if err := oemutil.CheckStructure(s, 28, TypeDeviceCorrelation); err != nil {
+		return nil, err
+	}
+
+	f := s.Formatted
+	ret := &DeviceCorrelation{
+		Handle:                 s.Header.Handle,
+		AssociatedDeviceHandle: binary.LittleEndian.Uint16(f[0:2]),
+		AssociatedSMBusHandle:  binary.LittleEndian.Uint16(f[2:4]),
+		PCIVendorID:            binary.LittleEndian.Uint16(f[4:6]),
+		PCIDeviceID:            binary.LittleEndian.Uint16(f[6:8]),
+		PCISubVendorID:         binary.LittleEndian.Uint16(f[8:10]),
+		PCISubDeviceID:         binary.LittleEndian.Uint16(f[10:12]),
+		ClassCode:              f[12],
+		SubClassCode:           f[13],
+		ParentHandle:           binary.LittleEndian.Uint16(f[14:16]),
+		Flags:                  binary.LittleEndian.Uint16(f[16:18]),
+		DeviceType:             lookup(Device_Type, f[18]),
+		DeviceLocation:         lookup(Device_Location, f[19]),
+		DeviceInstance:         f[20],
+		DeviceSubInstance:      f[21],
+		Bay:                    f[22],
+		Enclosure:              f[23],
+		UEFIDevicePath:         s.StringAt(24),
+		StructuredName:         s.StringAt(25),
+		DeviceName:             s.StringAt(26),
+		UEFILocation:           s.StringAt(27),
+	}
+	if len(f) >= 32 {
+		ret.Segment = binary.LittleEndian.Uint16(f[28:30])
+		ret.Bus = f[30]
+		ret.DeviceFunction = f[31]
+	}
+
+	return ret, nil
+}
+
+// lookup 在 names 中查找 n，找不到时返回 "Unknown"
+func lookup(names []string, n uint8) string {
+	if int(n) < len(names) {
+		return names[n]
+	}
+	return "Unknown"
+}
+
+var Device_Type = []string{
+	"Unknown", /* 0x00 */
+	"Reserved",
+	"Reserved",
+	"Flexible LOM",
+	"Embedded LOM",
+	"NIC in a Slot",
+	"Storage Controller",
+	"Smart Array Storage Controller",
+	"USB Hard Disk",
+	"Other PCI Device",
+	"RAM Disk",
+	"Firmware Volume",
+	"UEFI Shell",
+	"Generic UEFI USB Boot Entry",
+	"Dynamic Smart Array Controller",
+	"File",
+	"NVMe Hard Drive",
+	"NVDIMM", /* 0x11 */
+}
+
+var Device_Location = []string{
+	"Unknown", /* 0x00 */
+	"Embedded",
+	"iLO Virtual Media",
+	"Front USB Port",
+	"Rear USB Port",
+	"Internal USB",
+	"Internal SD Card",
+	"Internal Virtual USB (Embedded NAND)",
+	"Embedded SATA Port",
+	"Embedded Smart Array",
+	"PCI Slot",
+	"RAM Memory",
+	"USB",
+	"Dynamic Smart Array Controller",
+	"URL",
+	"NVMe Drive Bay", /* 0x0F */
+}
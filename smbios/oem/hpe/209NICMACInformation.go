@@ -0,0 +1,52 @@
+package hpe
+
+import (
+	"net"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// NICMACInformation 板载网卡的 PCI 位置和 MAC 地址（BIOS PXE 使用）
+type NICMACInformation struct { // type 209
+	Handle uint16
+	NICs   []*NICMAC // 4 每个 8 字节
+}
+
+// NICMAC 一个网口
+type NICMAC struct {
+	Disabled     bool             // 总线号和设备号都为 0
+	NotInstalled bool             // 总线号和设备号都为 0xFF，其余字段为零值
+	Bus          uint8            // 1
+	Device       uint8            // 0 bit 7:3
+	Function     uint8            // 0 bit 2:0
+	MAC          net.HardwareAddr // 2-7
+}
+
+// ParseNICMACInformation 解析structure
+func ParseNICMACInformation(s *smbios.Structure) (*NICMACInformation, error) {
+	if err := oemutil.CheckStructure(s, 0, TypeNICMACInformation); err != nil {
+		return nil, err
+	}
+
+	ret := &NICMACInformation{Handle: s.Header.Handle}
+	for b := s.Formatted; len(b) >= 8; b = b[8:] {
+		// 与 dmidecode 一样，FF FF 表示网口没有安装，后面的 MAC 没有意义
+		if b[0] == 0xFF && b[1] == 0xFF {
+			ret.NICs = append(ret.NICs, &NICMAC{NotInstalled: true})
+			continue
+		}
+
+		n := &NICMAC{
+			Disabled: b[0] == 0 && b[1] == 0,
+			Bus:      b[1],
+			Device:   b[0] >> 3,
+			Function: b[0] & 0x07,
+			MAC:      make(net.HardwareAddr, 6),
+		}
+		copy(n.MAC, b[2:8])
+		ret.NICs = append(ret.NICs, n)
+	}
+
+	return ret, nil
+}
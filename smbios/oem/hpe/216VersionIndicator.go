@@ -0,0 +1,40 @@
+package hpe
+
+import (
+	"encoding/binary"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// VersionIndicator 固件版本记录，系统 ROM、iLO、CPLD 等各有一条
+type VersionIndicator struct { // type 216
+	Handle      uint16
+	Type        uint16 // 4-5
+	Name        string // 6 String number
+	Version     string // 7 String number
+	DataFormat  uint8  // 8
+	VersionData []byte // 9-20 格式由 DataFormat 决定
+	UniqueID    uint16 // 21-22
+}
+
+// ParseVersionIndicator 解析structure
+func ParseVersionIndicator(s *smbios.Structure) (*VersionIndicator, error) {
+	if err := oemutil.CheckStructure(s, 19, TypeVersionIndicator); err != nil {
+		return nil, err
+	}
+
+	f := s.Formatted
+	ret := &VersionIndicator{
+		Handle:      s.Header.Handle,
+		Type:        binary.LittleEndian.Uint16(f[0:2]),
+		Name:        s.StringAt(2),
+		Version:     s.StringAt(3),
+		DataFormat:  f[4],
+		VersionData: make([]byte, 12),
+		UniqueID:    binary.LittleEndian.Uint16(f[17:19]),
+	}
+	copy(ret.VersionData, f[5:17])
+
+	return ret, nil
+}
@@ -0,0 +1,34 @@
+package hpe
+
+import (
+	"encoding/binary"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// PowerSupply 电源补充信息，关联到标准的 type 39
+type PowerSupply struct { // type 230
+	Handle           uint16
+	AssociatedHandle uint16 // 4-5 type 39
+	Manufacturer     string // 6 String number
+	Revision         string // 7 String number
+	FRUAccessAddress uint8  // 8 FFh 表示不支持
+	I2CBus           uint8  // 9 FRU 所在的 I2C 总线号
+}
+
+// ParsePowerSupply 解析structure
+func ParsePowerSupply(s *smbios.Structure) (*PowerSupply, error) {
+	if err := oemutil.CheckStructure(s, 7, TypePowerSupply); err != nil {
+		return nil, err
+	}
+
+	return &PowerSupply{
+		Handle:           s.Header.Handle,
+		AssociatedHandle: binary.LittleEndian.Uint16(s.Formatted[0:2]),
+		Manufacturer:     s.StringAt(2),
+		Revision:         s.StringAt(3),
+		FRUAccessAddress: s.Formatted[4],
+		I2CBus:           s.Formatted[5],
+	}, nil
+}
@@ -0,0 +1,43 @@
+package hpe
+
+import (
+	"encoding/binary"
+	"net"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// ServerNIC 网口的 PCI 位置和 MAC 地址，每个网口一条记录
+type ServerNIC struct { // type 233
+	Handle   uint16
+	Segment  uint16           // 4-5
+	Bus      uint8            // 6
+	Device   uint8            // 7 bit 7:3
+	Function uint8            // 7 bit 2:0
+	MAC      net.HardwareAddr // 8-39 前 6 字节，其余补 0
+	Port     uint8            // 40 长度大于 0x28 时才有，否则为 0
+}
+
+// ParseServerNIC 解析structure
+func ParseServerNIC(s *smbios.Structure) (*ServerNIC, error) {
+	if err := oemutil.CheckStructure(s, 10, TypeServerNIC); err != nil {
+		return nil, err
+	}
+
+	f := s.Formatted
+	ret := &ServerNIC{
+		Handle:   s.Header.Handle,
+		Segment:  binary.LittleEndian.Uint16(f[0:2]),
+		Bus:      f[2],
+		Device:   f[3] >> 3,
+		Function: f[3] & 0x07,
+		MAC:      make(net.HardwareAddr, 6),
+	}
+	copy(ret.MAC, f[4:10])
+	if len(f) > 36 {
+		ret.Port = f[36]
+	}
+
+	return ret, nil
+}
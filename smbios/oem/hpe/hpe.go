@@ -0,0 +1,102 @@
+// Package hpe 解析 HPE ProLiant 服务器的 OEM structure：type 203 的 PCI 设备关联、
+// 209/233 的网口 MAC 地址、216 的固件版本（包括 iLO）和 230 的电源信息。
+//
+// 记录格式参考 dmidecode 对 HP/HPE 记录的解析。较老的 HP 机器也使用同样的类型号，
+// Detect 同时认 "HP" 和 "Hewlett" 开头的厂商名。
+package hpe
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+// HPE OEM structure 类型
+const (
+	TypeDeviceCorrelation = 203 // 0xCB
+	TypeNICMACInformation = 209 // 0xD1
+	TypeVersionIndicator  = 216 // 0xD8
+	TypePowerSupply       = 230 // 0xE6
+	TypeServerNIC         = 233 // 0xE9
+)
+
+// Records 保存从一张 SMBIOS 表中解析出来的 HPE OEM 记录
+type Records struct {
+	DeviceCorrelations []*DeviceCorrelation // type 203
+	NICMACInformations []*NICMACInformation // type 209
+	VersionIndicators  []*VersionIndicator  // type 216
+	PowerSupplies      []*PowerSupply       // type 230
+	ServerNICs         []*ServerNIC         // type 233
+}
+
+// Detect 根据 type 1 的厂商名判断是否是 HPE/HP 的机器
+func Detect(ss []*smbios.Structure) bool {
+	for _, s := range ss {
+		if s.Header.Type != 1 {
+			continue
+		}
+		m := strings.ToUpper(s.StringAt(0))
+		return strings.HasPrefix(m, "HP") || strings.HasPrefix(m, "HEWLETT")
+	}
+	return false
+}
+
+// Parse 解析 ss 中所有已知的 HPE OEM 记录，其他 structure 会被忽略。
+// 格式不正确的记录会被跳过，原因通过 errs 返回，不影响其他记录。
+func Parse(ss []*smbios.Structure) (ret *Records, errs []error) {
+	ret = &Records{}
+	for _, s := range ss {
+		var err error
+		switch s.Header.Type {
+		case TypeDeviceCorrelation:
+			var out *DeviceCorrelation
+			if out, err = ParseDeviceCorrelation(s); err == nil {
+				ret.DeviceCorrelations = append(ret.DeviceCorrelations, out)
+			}
+		case TypeNICMACInformation:
+			var out *NICMACInformation
+			if out, err = ParseNICMACInformation(s); err == nil {
+				ret.NICMACInformations = append(ret.NICMACInformations, out)
+			}
+		case TypeVersionIndicator:
+			var out *VersionIndicator
+			if out, err = ParseVersionIndicator(s); err == nil {
+				ret.VersionIndicators = append(ret.VersionIndicators, out)
+			}
+		case TypePowerSupply:
+			var out *PowerSupply
+			if out, err = ParsePowerSupply(s); err == nil {
+				ret.PowerSupplies = append(ret.PowerSupplies, out)
+			}
+		case TypeServerNIC:
+			var out *ServerNIC
+			if out, err = ParseServerNIC(s); err == nil {
+				ret.ServerNICs = append(ret.ServerNICs, out)
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("handle %#04x: %v", s.Header.Handle, err))
+		}
+	}
+	return ret, errs
+}
+
+// ManagementProcessor 返回 iLO 管理处理器的固件版本记录，没有找到时返回 nil
+func (r *Records) ManagementProcessor() *VersionIndicator {
+	for _, v := range r.VersionIndicators {
+		if strings.HasPrefix(v.Name, "iLO") {
+			return v
+		}
+	}
+	return nil
+}
+
+// Register 把本包的解析器注册到 smbios 的解析器注册表中
+func Register() {
+	smbios.RegisterParser(TypeDeviceCorrelation, func(s *smbios.Structure) (interface{}, error) { return ParseDeviceCorrelation(s) })
+	smbios.RegisterParser(TypeNICMACInformation, func(s *smbios.Structure) (interface{}, error) { return ParseNICMACInformation(s) })
+	smbios.RegisterParser(TypeVersionIndicator, func(s *smbios.Structure) (interface{}, error) { return ParseVersionIndicator(s) })
+	smbios.RegisterParser(TypePowerSupply, func(s *smbios.Structure) (interface{}, error) { return ParsePowerSupply(s) })
+	smbios.RegisterParser(TypeServerNIC, func(s *smbios.Structure) (interface{}, error) { return ParseServerNIC(s) })
+}
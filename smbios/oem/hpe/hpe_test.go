@@ -0,0 +1,165 @@
+package hpe_test
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/hpe"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+func TestParse(t *testing.T) {
+	b := oemutil.MarshalTable(
+		oemutil.MarshalStructure(1, 0x0001, append([]byte{0x01}, make([]byte, 23)...), "HPE"),
+		oemutil.MarshalStructure(203, 0x0010, []byte{
+			0x09, 0x00, 0xFE, 0xFF,
+			0xE4, 0x14, 0x57, 0x16, 0x90, 0x15, 0xDC, 0x22,
+			0x02, 0x00,
+			0xFE, 0xFF, 0x00, 0x00,
+			0x03, 0x01, 0x01, 0x02, 0x00, 0x00,
+			0x01, 0x02, 0x03, 0x00,
+			0x00, 0x00, 0x5C, 0x00,
+		}, "PciRoot(0x0)/Pci(0x1C,0x0)/Pci(0x0,0x0)", "NIC.FlexLOM.1.1", "HPE Ethernet 1Gb 4-port"),
+		oemutil.MarshalStructure(209, 0x0011, []byte{
+			0x00, 0x02, 0x98, 0xF2, 0xB3, 0x11, 0x22, 0x33,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		}),
+		oemutil.MarshalStructure(216, 0x0012, []byte{
+			0x02, 0x00, 0x01, 0x02, 0x0A,
+			0x02, 0x2C, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00,
+		}, "iLO 5", "2.44 Mar 31 2021"),
+		oemutil.MarshalStructure(230, 0x0013, []byte{0x20, 0x00, 0x01, 0x02, 0xB0, 0x03, 0x00}, "DELTA", "1.00"),
+		oemutil.MarshalStructure(233, 0x0014, append(append([]byte{
+			0x00, 0x00, 0x5C, 0x01,
+			0x98, 0xF2, 0xB3, 0x11, 0x22, 0x34,
+		}, make([]byte, 26)...), 0x02)),
+		// Without a port number.
+		oemutil.MarshalStructure(233, 0x0015, []byte{
+			0x00, 0x00, 0x5C, 0x00,
+			0x98, 0xF2, 0xB3, 0x11, 0x22, 0x35,
+		}),
+		oemutil.MarshalStructure(127, 0x00FF, nil),
+	)
+
+	ss, err := smbios.NewDecoder(bytes.NewReader(b)).Decode()
+	if err != nil {
+		t.Fatalf("failed to decode synthetic table: %v", err)
+	}
+
+	if !hpe.Detect(ss) {
+		t.Fatal("expected synthetic table to be detected as HPE")
+	}
+
+	got, errs := hpe.Parse(ss)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	want := &hpe.Records{
+		DeviceCorrelations: []*hpe.DeviceCorrelation{{
+			Handle:                 0x0010,
+			AssociatedDeviceHandle: 0x0009,
+			AssociatedSMBusHandle:  0xFFFE,
+			PCIVendorID:            0x14E4,
+			PCIDeviceID:            0x1657,
+			PCISubVendorID:         0x1590,
+			PCISubDeviceID:         0x22DC,
+			ClassCode:              0x02,
+			ParentHandle:           0xFFFE,
+			DeviceType:             "Flexible LOM",
+			DeviceLocation:         "Embedded",
+			DeviceInstance:         1,
+			DeviceSubInstance:      2,
+			UEFIDevicePath:         "PciRoot(0x0)/Pci(0x1C,0x0)/Pci(0x0,0x0)",
+			StructuredName:         "NIC.FlexLOM.1.1",
+			DeviceName:             "HPE Ethernet 1Gb 4-port",
+			Bus:                    0x5C,
+		}},
+		NICMACInformations: []*hpe.NICMACInformation{{
+			Handle: 0x0011,
+			NICs: []*hpe.NICMAC{
+				{Bus: 0x02, MAC: mac("98:f2:b3:11:22:33")},
+				{Disabled: true, MAC: mac("00:00:00:00:00:00")},
+				{NotInstalled: true},
+			},
+		}},
+		VersionIndicators: []*hpe.VersionIndicator{{
+			Handle:      0x0012,
+			Type:        0x0002,
+			Name:        "iLO 5",
+			Version:     "2.44 Mar 31 2021",
+			DataFormat:  0x0A,
+			VersionData: []byte{0x02, 0x2C, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		}},
+		PowerSupplies: []*hpe.PowerSupply{{
+			Handle:           0x0013,
+			AssociatedHandle: 0x0020,
+			Manufacturer:     "DELTA",
+			Revision:         "1.00",
+			FRUAccessAddress: 0xB0,
+			I2CBus:           0x03,
+		}},
+		ServerNICs: []*hpe.ServerNIC{
+			{
+				Handle:   0x0014,
+				Bus:      0x5C,
+				Function: 1,
+				MAC:      mac("98:f2:b3:11:22:34"),
+				Port:     2,
+			},
+			{
+				Handle: 0x0015,
+				Bus:    0x5C,
+				MAC:    mac("98:f2:b3:11:22:35"),
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected records (-want +got):\n%s", diff)
+	}
+
+	if mp := got.ManagementProcessor(); mp == nil || mp.Version != "2.44 Mar 31 2021" {
+		t.Fatalf("unexpected management processor: %+v", mp)
+	}
+}
+
+func TestParseTruncated(t *testing.T) {
+	ss := []*smbios.Structure{
+		{
+			Header:    smbios.Header{Type: hpe.TypeVersionIndicator, Length: 8, Handle: 0x0012},
+			Formatted: []byte{0x02, 0x00, 0x01, 0x02},
+		},
+		{
+			Header:    smbios.Header{Type: hpe.TypePowerSupply, Length: 9, Handle: 0x0013},
+			Formatted: []byte{0x20, 0x00, 0x01, 0x02, 0xB0},
+		},
+		{
+			Header:    smbios.Header{Type: hpe.TypeServerNIC, Length: 14, Handle: 0x0014},
+			Formatted: []byte{0x00, 0x00, 0x5C, 0x01, 0x98, 0xF2, 0xB3, 0x11, 0x22, 0x34},
+		},
+	}
+
+	got, errs := hpe.Parse(ss)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, but got: %v", errs)
+	}
+
+	// The malformed records are skipped, the rest are still parsed.
+	if len(got.VersionIndicators) != 0 || len(got.PowerSupplies) != 0 || len(got.ServerNICs) != 1 {
+		t.Fatalf("unexpected records: %+v", got)
+	}
+}
+
+func mac(s string) net.HardwareAddr {
+	m, err := net.ParseMAC(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
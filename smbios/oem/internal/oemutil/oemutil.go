@@ -0,0 +1,50 @@
+// Package oemutil 是 dell、hpe、lenovo 等 OEM 包共用的辅助函数，包括检查
+// structure 的类型和长度，以及在测试中构造 SMBIOS 表。
+package oemutil
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+// CheckStructure 检查 structure 的类型是 types 之一，并且 formatted 区域至少有
+// minLen 字节
+func CheckStructure(s *smbios.Structure, minLen int, types ...uint8) error {
+	if s == nil {
+		return fmt.Errorf("structure s is null")
+	}
+	for _, t := range types {
+		if s.Header.Type == t {
+			if len(s.Formatted) < minLen {
+				return fmt.Errorf("structure s is too short for type %d: %d bytes", t, len(s.Formatted))
+			}
+			return nil
+		}
+	}
+	if len(types) == 1 {
+		return fmt.Errorf("structure s is not type %d, but %d", types[0], s.Header.Type)
+	}
+	return fmt.Errorf("structure s is not type %v, but %d", types, s.Header.Type)
+}
+
+// MarshalStructure 把一个 structure 编码成它在 SMBIOS 表中的形式，用于测试
+func MarshalStructure(typ uint8, handle uint16, formatted []byte, strs ...string) []byte {
+	b := []byte{typ, uint8(4 + len(formatted)), uint8(handle), uint8(handle >> 8)}
+	b = append(b, formatted...)
+
+	if len(strs) == 0 {
+		return append(b, 0x00, 0x00)
+	}
+	for _, s := range strs {
+		b = append(b, s...)
+		b = append(b, 0x00)
+	}
+	return append(b, 0x00)
+}
+
+// MarshalTable 把编码后的 structure 拼接成一张 SMBIOS 表，用于测试
+func MarshalTable(ss ...[]byte) []byte {
+	return bytes.Join(ss, nil)
+}
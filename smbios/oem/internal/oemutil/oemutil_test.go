@@ -0,0 +1,47 @@
+package oemutil_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+func TestMarshalTable(t *testing.T) {
+	b := oemutil.MarshalTable(
+		oemutil.MarshalStructure(1, 0x0001, []byte{0x01}, "Vendor"),
+		oemutil.MarshalStructure(127, 0x0002, nil),
+	)
+
+	got, err := smbios.NewDecoder(bytes.NewReader(b)).Decode()
+	if err != nil {
+		t.Fatalf("failed to decode table: %v", err)
+	}
+
+	want := []*smbios.Structure{
+		{
+			Header:    smbios.Header{Type: 1, Length: 5, Handle: 0x0001},
+			Formatted: []byte{0x01},
+			Strings:   []string{"Vendor"},
+		},
+		{
+			Header: smbios.Header{Type: 127, Length: 4, Handle: 0x0002},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected structures (-want +got):\n%s", diff)
+	}
+
+	if err := oemutil.CheckStructure(got[0], 1, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := oemutil.CheckStructure(got[0], 2, 1); err == nil {
+		t.Fatal("expected an error for a short structure, but none occurred")
+	}
+	if err := oemutil.CheckStructure(got[0], 1, 2, 3); err == nil {
+		t.Fatal("expected an error for the wrong type, but none occurred")
+	}
+}
@@ -0,0 +1,52 @@
+package lenovo
+
+import (
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// thinkVantageSignature 是 type 131 第一个字符串的固定值
+const thinkVantageSignature = "TVT-Enablement"
+
+// thinkVantageLen 是 type 131 formatted 区域的长度，dmidecode 要求长度为 0x16
+const thinkVantageLen = 0x16 - 4
+
+// diagnosticsBit 表示可以使用诊断工具（PC-Doctor）
+const diagnosticsBit = 127
+
+// ThinkVantage ThinkVantage 技术的特性位
+type ThinkVantage struct { // type 131
+	Handle      uint16
+	Version     uint8  // 4
+	Diagnostics bool   // 第 127 位，也就是 20 的 bit 7
+	FeatureBits []byte // 5-20 共 128 位，每一位表示一项 TVT 特性，小端
+}
+
+// ParseThinkVantage 解析structure
+func ParseThinkVantage(s *smbios.Structure) (*ThinkVantage, error) {
+	if err := oemutil.CheckStructure(s, 0, TypeThinkVantage); err != nil {
+		return nil, err
+	}
+	if len(s.Formatted) != thinkVantageLen || len(s.Strings) == 0 || s.Strings[0] != thinkVantageSignature {
+		return nil, ErrUnrecognized
+	}
+
+	f := s.Formatted
+	ret := &ThinkVantage{
+		Handle:      s.Header.Handle,
+		Version:     f[0],
+		FeatureBits: make([]byte, 16),
+	}
+	copy(ret.FeatureBits, f[1:17])
+	ret.Diagnostics = ret.Feature(diagnosticsBit)
+
+	return ret, nil
+}
+
+// Feature 返回第 n 个 TVT 特性位（从 0 开始）是否置位
+func (t *ThinkVantage) Feature(n int) bool {
+	if n < 0 || n/8 >= len(t.FeatureBits) {
+		return false
+	}
+	return t.FeatureBits[n/8]&(1<<uint(n%8)) != 0
+}
@@ -0,0 +1,59 @@
+package lenovo
+
+import (
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// DevicePresence ThinkPad 设备存在检测位，每一位表示一种设备（指纹、WWAN 等）
+// 是否存在，位的含义随机型变化，这里按原样保留
+type DevicePresence struct { // type 133, 135
+	Handle   uint16
+	Type     uint8
+	Header   Header // 4-8 签名是 "TP"，不是 "LENOVO"
+	Presence []byte // 9 起，bit 0 是指纹识别器
+}
+
+// devicePresenceSignature 是设备存在检测记录开头的 2 个 ASCII 字节
+const devicePresenceSignature = "TP"
+
+// devicePresenceHeaderLen 是设备存在检测记录头在 formatted 区域中的长度
+const devicePresenceHeaderLen = len(devicePresenceSignature) + 3
+
+// ParseDevicePresence 解析structure。与 dmidecode 一样，这类记录以 "TP" 开头，
+// 后面是 offset/number/revision（通常是 07h/03h/01h），以 "LENOVO" 开头的记录
+// 不是这种格式，返回 ErrUnrecognized。
+func ParseDevicePresence(s *smbios.Structure) (*DevicePresence, error) {
+	if err := oemutil.CheckStructure(s, devicePresenceHeaderLen+1, TypeDevicePresence, TypeDevicePresence2); err != nil {
+		return nil, err
+	}
+
+	f := s.Formatted
+	h := Header{
+		Signature: string(f[0:2]),
+		Offset:    f[2],
+		Number:    f[3],
+		Revision:  f[4],
+	}
+	if h.Signature != devicePresenceSignature {
+		return nil, ErrUnrecognized
+	}
+
+	ret := &DevicePresence{
+		Handle:   s.Header.Handle,
+		Type:     s.Header.Type,
+		Header:   h,
+		Presence: make([]byte, len(f)-devicePresenceHeaderLen),
+	}
+	copy(ret.Presence, f[devicePresenceHeaderLen:])
+
+	return ret, nil
+}
+
+// Present 返回第 n 位（从 0 开始，小端）是否置位
+func (d *DevicePresence) Present(n int) bool {
+	if n < 0 || n/8 >= len(d.Presence) {
+		return false
+	}
+	return d.Presence[n/8]&(1<<uint(n%8)) != 0
+}
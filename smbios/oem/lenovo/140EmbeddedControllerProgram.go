@@ -0,0 +1,36 @@
+package lenovo
+
+import (
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+)
+
+// EmbeddedControllerProgram ThinkPad 嵌入式控制器（EC）固件信息
+type EmbeddedControllerProgram struct { // type 140
+	Handle      uint16
+	Header      Header // 4-12
+	VersionID   string // 13 String number
+	ReleaseDate string // 14 String number
+}
+
+// ParseEmbeddedControllerProgram 解析structure
+func ParseEmbeddedControllerProgram(s *smbios.Structure) (*EmbeddedControllerProgram, error) {
+	if err := oemutil.CheckStructure(s, headerLen+2, TypeEmbeddedControllerProgram); err != nil {
+		return nil, err
+	}
+	h, err := parseHeader(s)
+	if err != nil {
+		return nil, err
+	}
+	// 0x0B/0x07/0x01 表示 ThinkPad EC 程序记录
+	if h.Offset != 0x0B || h.Number != 0x07 || h.Revision != 0x01 {
+		return nil, ErrUnrecognized
+	}
+
+	return &EmbeddedControllerProgram{
+		Handle:      s.Header.Handle,
+		Header:      h,
+		VersionID:   s.StringAt(headerLen),
+		ReleaseDate: s.StringAt(headerLen + 1),
+	}, nil
+}
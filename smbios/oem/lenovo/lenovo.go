@@ -0,0 +1,116 @@
+// Package lenovo 解析 Lenovo ThinkPad 的 OEM structure：type 131 的 ThinkVantage
+// 技术特性位、133/135 的设备存在检测和 140 的嵌入式控制器（EC）固件版本。
+//
+// 这些记录继承自 IBM，type 133/135 以 "TP" 签名开头，140 以 "LENOVO" 签名开头，
+// 签名不符的记录会当作其他产品线复用的类型号而跳过。ThinkSystem 服务器的 XClarity 记录没有
+// 公开文档，目前不解析。
+package lenovo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+// Lenovo OEM structure 类型
+const (
+	TypeThinkVantage              = 131 // 0x83
+	TypeDevicePresence            = 133 // 0x85
+	TypeDevicePresence2           = 135 // 0x87
+	TypeEmbeddedControllerProgram = 140 // 0x8C
+)
+
+// signature 是 Lenovo OEM 记录头开头的 6 个 ASCII 字节
+const signature = "LENOVO"
+
+// headerLen 是 Lenovo OEM 记录头在 formatted 区域中的长度
+const headerLen = len(signature) + 3
+
+// ErrUnrecognized 表示 structure 的类型号匹配，但签名或格式版本不是本包认识的，
+// 通常是其他产品线复用了同一个类型号
+var ErrUnrecognized = errors.New("unrecognized Lenovo OEM record")
+
+// Records 保存从一张 SMBIOS 表中解析出来的 Lenovo OEM 记录
+type Records struct {
+	ThinkVantage              *ThinkVantage              // type 131
+	DevicePresences           []*DevicePresence          // type 133, 135
+	EmbeddedControllerProgram *EmbeddedControllerProgram // type 140
+}
+
+// Header 是 type 133/135/140 等记录共用的头部，这里的偏移是 type 140 的，
+// type 133/135 的签名是 2 字节的 "TP"，后面的字段依次前移 4 字节
+type Header struct {
+	Signature string // 4-9 ASCII "LENOVO"，不是字符串编号
+	Offset    uint8  // 10 OEM 数据的偏移
+	Number    uint8  // 11 OEM 记录编号
+	Revision  uint8  // 12 OEM 记录格式版本
+}
+
+// Detect 根据 type 1 的厂商名判断是否是 Lenovo 的机器
+func Detect(ss []*smbios.Structure) bool {
+	for _, s := range ss {
+		if s.Header.Type == 1 {
+			return strings.HasPrefix(strings.ToUpper(s.StringAt(0)), signature)
+		}
+	}
+	return false
+}
+
+// Parse 解析 ss 中所有已知的 Lenovo OEM 记录，其他 structure 会被忽略。
+// 格式不正确的记录会被跳过，原因通过 errs 返回，不影响其他记录。
+func Parse(ss []*smbios.Structure) (ret *Records, errs []error) {
+	ret = &Records{}
+	for _, s := range ss {
+		var err error
+		switch s.Header.Type {
+		case TypeThinkVantage:
+			var out *ThinkVantage
+			if out, err = ParseThinkVantage(s); err == nil {
+				ret.ThinkVantage = out
+			}
+		case TypeDevicePresence, TypeDevicePresence2:
+			var out *DevicePresence
+			if out, err = ParseDevicePresence(s); err == nil {
+				ret.DevicePresences = append(ret.DevicePresences, out)
+			}
+		case TypeEmbeddedControllerProgram:
+			var out *EmbeddedControllerProgram
+			if out, err = ParseEmbeddedControllerProgram(s); err == nil {
+				ret.EmbeddedControllerProgram = out
+			}
+		}
+		if err == ErrUnrecognized {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("handle %#04x: %v", s.Header.Handle, err))
+		}
+	}
+	return ret, errs
+}
+
+// Register 把本包的解析器注册到 smbios 的解析器注册表中
+func Register() {
+	smbios.RegisterParser(TypeThinkVantage, func(s *smbios.Structure) (interface{}, error) { return ParseThinkVantage(s) })
+	smbios.RegisterParser(TypeDevicePresence, func(s *smbios.Structure) (interface{}, error) { return ParseDevicePresence(s) })
+	smbios.RegisterParser(TypeDevicePresence2, func(s *smbios.Structure) (interface{}, error) { return ParseDevicePresence(s) })
+	smbios.RegisterParser(TypeEmbeddedControllerProgram, func(s *smbios.Structure) (interface{}, error) { return ParseEmbeddedControllerProgram(s) })
+}
+
+// parseHeader 解析并校验以 "LENOVO" 开头的 OEM 记录头，调用方需要保证 formatted 区域至少有
+// headerLen 字节
+func parseHeader(s *smbios.Structure) (Header, error) {
+	f := s.Formatted
+	h := Header{
+		Signature: string(f[0:6]),
+		Offset:    f[6],
+		Number:    f[7],
+		Revision:  f[8],
+	}
+	if h.Signature != signature {
+		return h, ErrUnrecognized
+	}
+	return h, nil
+}
@@ -0,0 +1,149 @@
+package lenovo_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/internal/oemutil"
+	"github.com/lijingwei9060/go-smbios/smbios/oem/lenovo"
+)
+
+// thinkPadTable holds the Lenovo records of a ThinkPad table in the layout
+// dmidecode decodes, with the type 1 record shortened.
+var thinkPadTable = oemutil.MarshalTable(
+	oemutil.MarshalStructure(1, 0x000E, append([]byte{0x01}, make([]byte, 23)...), "LENOVO"),
+	// ThinkVantage Technologies: version 1, diagnostics available.
+	[]byte{
+		0x83, 0x16, 0x30, 0x00,
+		0x01,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80,
+		0x01,
+		'T', 'V', 'T', '-', 'E', 'n', 'a', 'b', 'l', 'e', 'm', 'e', 'n', 't', 0x00,
+		0x00,
+	},
+	// A type 131 record of another length and signature is not TVT.
+	oemutil.MarshalStructure(131, 0x0031, make([]byte, 60)),
+	// ThinkPad Device Presence Detection: fingerprint reader present.
+	[]byte{
+		0x87, 0x0A, 0x32, 0x00,
+		'T', 'P', 0x07, 0x03, 0x01,
+		0x01,
+		0x00, 0x00,
+	},
+	// ThinkPad Embedded Controller Program.
+	[]byte{
+		0x8C, 0x0F, 0x33, 0x00,
+		'L', 'E', 'N', 'O', 'V', 'O', 0x0B, 0x07, 0x01,
+		0x01, 0x02,
+		'N', '2', 'H', 'H', 'T', '3', '6', 'W', 0x00,
+		'2', '0', '2', '1', '/', '0', '6', '/', '0', '3', 0x00,
+		0x00,
+	},
+	oemutil.MarshalStructure(127, 0xFEFF, nil),
+)
+
+func TestParse(t *testing.T) {
+	ss, err := smbios.NewDecoder(bytes.NewReader(thinkPadTable)).Decode()
+	if err != nil {
+		t.Fatalf("failed to decode table: %v", err)
+	}
+
+	if !lenovo.Detect(ss) {
+		t.Fatal("expected table to be detected as Lenovo")
+	}
+
+	got, errs := lenovo.Parse(ss)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	header := lenovo.Header{Signature: "LENOVO", Offset: 0x0B, Number: 0x07, Revision: 0x01}
+	tvt := make([]byte, 16)
+	tvt[15] = 0x80
+
+	want := &lenovo.Records{
+		ThinkVantage: &lenovo.ThinkVantage{
+			Handle:      0x0030,
+			Version:     0x01,
+			Diagnostics: true,
+			FeatureBits: tvt,
+		},
+		DevicePresences: []*lenovo.DevicePresence{{
+			Handle:   0x0032,
+			Type:     135,
+			Header:   lenovo.Header{Signature: "TP", Offset: 0x07, Number: 0x03, Revision: 0x01},
+			Presence: []byte{0x01},
+		}},
+		EmbeddedControllerProgram: &lenovo.EmbeddedControllerProgram{
+			Handle:      0x0033,
+			Header:      header,
+			VersionID:   "N2HHT36W",
+			ReleaseDate: "2021/06/03",
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected records (-want +got):\n%s", diff)
+	}
+
+	if p := got.DevicePresences[0]; !p.Present(0) || p.Present(1) {
+		t.Fatalf("unexpected presence bits: %#v", p.Presence)
+	}
+	if v := got.ThinkVantage; !v.Feature(127) || v.Feature(0) {
+		t.Fatalf("unexpected TVT feature bits: %#v", v.FeatureBits)
+	}
+}
+
+func TestParseBadRecords(t *testing.T) {
+	ss := []*smbios.Structure{
+		// A signature other than LENOVO is another product line's record.
+		{
+			Header:    smbios.Header{Type: lenovo.TypeEmbeddedControllerProgram, Length: 0x0F, Handle: 0x0034},
+			Formatted: []byte{'O', 'T', 'H', 'E', 'R', ' ', 0x0B, 0x07, 0x01, 0x01, 0x02},
+		},
+		// The signature as a string number, which is not the layout of a
+		// real record.
+		{
+			Header:    smbios.Header{Type: lenovo.TypeEmbeddedControllerProgram, Length: 0x0A, Handle: 0x0033},
+			Formatted: []byte{0x01, 0x0B, 0x07, 0x01, 0x02, 0x03},
+			Strings:   []string{"LENOVO", "N2HHT36W", "2021/06/03"},
+		},
+		// Too short for the header.
+		{
+			Header:    smbios.Header{Type: lenovo.TypeDevicePresence2, Length: 0x08, Handle: 0x0032},
+			Formatted: []byte{'T', 'P', 0x07, 0x03},
+		},
+	}
+
+	got, errs := lenovo.Parse(ss)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, but got: %v", errs)
+	}
+	if got.EmbeddedControllerProgram != nil || len(got.DevicePresences) != 0 {
+		t.Fatalf("unexpected records: %+v", got)
+	}
+}
+
+func TestParseDevicePresenceLenovoHeader(t *testing.T) {
+	// A LENOVO header is the type 140 layout, not the one of a ThinkPad
+	// device presence record.
+	s := &smbios.Structure{
+		Header: smbios.Header{Type: lenovo.TypeDevicePresence2, Length: 0x0E, Handle: 0x0032},
+		Formatted: []byte{
+			'L', 'E', 'N', 'O', 'V', 'O', 0x0B, 0x07, 0x01,
+			0x01,
+		},
+	}
+
+	if _, err := lenovo.ParseDevicePresence(s); err != lenovo.ErrUnrecognized {
+		t.Fatalf("expected ErrUnrecognized, but got: %v", err)
+	}
+
+	got, errs := lenovo.Parse([]*smbios.Structure{s})
+	if len(errs) > 0 || len(got.DevicePresences) != 0 {
+		t.Fatalf("unexpected result: %+v, %v", got, errs)
+	}
+}
@@ -0,0 +1,60 @@
+package smbios
+
+import (
+	"sync"
+)
+
+// A ParserFunc parses a Structure into a typed value.  It is used to plug
+// parsers for additional structure types, such as the OEM-specific types
+// 128-255, into GetSMBIOS and ParseSMBIOS.
+type ParserFunc func(s *Structure) (interface{}, error)
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = make(map[uint8]ParserFunc)
+)
+
+// RegisterParser registers fn as the parser for structures of type t,
+// replacing any parser previously registered for that type, including the
+// built-in ones.  Passing a nil fn removes the parser for type t.
+//
+// RegisterParser is safe for concurrent use, and is typically called from
+// an init function.
+func RegisterParser(t uint8, fn ParserFunc) {
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+
+	if fn == nil {
+		delete(parsers, t)
+		return
+	}
+	parsers[t] = fn
+}
+
+// lookupParser returns the parser registered for structures of type t.
+func lookupParser(t uint8) (ParserFunc, bool) {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+
+	fn, ok := parsers[t]
+	return fn, ok
+}
+
+func init() {
+	// Built-in parsers.
+	RegisterParser(0, func(s *Structure) (interface{}, error) { return ParseBIOSInformation(s) })
+	RegisterParser(1, func(s *Structure) (interface{}, error) { return ParseSystemInformation(s) })
+	RegisterParser(2, func(s *Structure) (interface{}, error) { return ParseBaseboardInformation(s) })
+	RegisterParser(3, func(s *Structure) (interface{}, error) { return ParseSystemEnclosure(s) })
+	RegisterParser(4, func(s *Structure) (interface{}, error) { return ParseProcessorInformation(s) })
+	RegisterParser(5, func(s *Structure) (interface{}, error) { return ParseMemoryController(s) })
+	RegisterParser(6, func(s *Structure) (interface{}, error) { return ParseMemoryModule(s) })
+	RegisterParser(17, func(s *Structure) (interface{}, error) { return ParseMemoryDevice(s) })
+	RegisterParser(30, func(s *Structure) (interface{}, error) { return ParseOutOfBandRemoteAccess(s) })
+	RegisterParser(31, func(s *Structure) (interface{}, error) { return ParseBootIntegrityServices(s) })
+	RegisterParser(34, func(s *Structure) (interface{}, error) { return ParseManagementDevice(s) })
+	RegisterParser(35, func(s *Structure) (interface{}, error) { return ParseManagementDeviceComponent(s) })
+	RegisterParser(36, func(s *Structure) (interface{}, error) { return ParseManagementDeviceThresholdData(s) })
+	RegisterParser(37, func(s *Structure) (interface{}, error) { return ParseMemoryChannel(s) })
+	RegisterParser(40, func(s *Structure) (interface{}, error) { return ParseAdditionalInformation(s) })
+}
@@ -0,0 +1,43 @@
+package smbios_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+type oemRecord struct {
+	Handle uint16
+	Value  uint8
+}
+
+func TestRegisterParser(t *testing.T) {
+	const oemType = 0xDD
+
+	smbios.RegisterParser(oemType, func(s *smbios.Structure) (interface{}, error) {
+		return &oemRecord{Handle: s.Header.Handle, Value: s.Formatted[0]}, nil
+	})
+	defer smbios.RegisterParser(oemType, nil)
+
+	oem := &smbios.Structure{
+		Header:    smbios.Header{Type: oemType, Length: 5, Handle: 0x0100},
+		Formatted: []byte{0x2A},
+	}
+	unknown := &smbios.Structure{
+		Header:    smbios.Header{Type: 0xDE, Length: 5, Handle: 0x0101},
+		Formatted: []byte{0x00},
+	}
+	eot := &smbios.Structure{
+		Header: smbios.Header{Type: 127, Length: 4, Handle: 0x0102},
+	}
+
+	got := smbios.ParseSMBIOS(nil, []*smbios.Structure{oem, unknown, eot})
+
+	if diff := cmp.Diff([]interface{}{&oemRecord{Handle: 0x0100, Value: 0x2A}}, got.Parsed[oemType]); diff != "" {
+		t.Fatalf("unexpected parsed OEM records (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*smbios.Structure{unknown, eot}, got.Unparsed); diff != "" {
+		t.Fatalf("unexpected unparsed structures (-want +got):\n%s", diff)
+	}
+}
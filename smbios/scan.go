@@ -0,0 +1,237 @@
+package smbios
+
+import (
+	"bytes"
+	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// scanChunk is the number of bytes ScanImage reads at a time.
+const scanChunk = 64 * 1024
+
+// ScanOptions controls how ScanImage searches an image.  The zero value
+// searches every 16 byte paragraph of an image whose offsets are physical
+// addresses, such as a raw guest memory dump.
+type ScanOptions struct {
+	// Align is the alignment of the entry points searched for.  The
+	// specification places entry points on 16 byte paragraphs, which is
+	// the default; 1 searches every byte.
+	Align int
+
+	// Base is the physical address of offset 0 of the image, subtracted
+	// from table addresses to find the table in the image.  It is non-zero
+	// for a firmware image which is mapped below 4 GiB, for example.
+	Base int64
+
+	// Decode, if not nil, is used to decode every table instead of
+	// the EntryPointDecodeOptions of its entry point.
+	Decode *DecodeOptions
+}
+
+// A ScanResult is an SMBIOS table found by ScanImage.
+type ScanResult struct {
+	// Offset is the offset of the entry point in the image.
+	Offset int64
+
+	EntryPoint EntryPoint
+	Structures []*Structure
+
+	// Warnings are the problems DecodeWith reported for the table.
+	Warnings []error
+}
+
+// ScanImage searches the first size bytes of r for every SMBIOS 2.x, 3.x and
+// legacy DMI entry point anchor, and decodes the table of each entry point
+// whose checksum is valid.  It is meant for images which are not the memory
+// of the running system, such as raw guest memory dumps and firmware
+// volumes, which may hold several tables or stale copies of one.
+//
+// An ELF64 core file, such as a kdump vmcore, is searched through the
+// physical memory its PT_LOAD segments hold, as OpenVMCore reads it: the
+// Offset of each result is then a physical address and opts.Base is
+// ignored.
+//
+// Candidates whose table lies outside of the image or cannot be decoded are
+// skipped.  An error is returned if r cannot be read or no table is found.
+func ScanImage(r io.ReaderAt, size int64, opts *ScanOptions) ([]*ScanResult, error) {
+	if opts == nil {
+		opts = &ScanOptions{}
+	}
+	align := int64(opts.Align)
+	if align <= 0 {
+		align = 16
+	}
+
+	if isELFCore(r, size) {
+		return scanCore(r, size, align, opts)
+	}
+
+	offs, err := scanAnchors(r, size, align)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanEntryPoints(r, size, offs, opts)
+}
+
+// isELFCore reports whether the first size bytes of r hold an ELF core file.
+func isELFCore(r io.ReaderAt, size int64) bool {
+	b := make([]byte, len(elf.ELFMAG))
+	if _, err := r.ReadAt(b, 0); err != nil || string(b) != elf.ELFMAG {
+		return false
+	}
+
+	f, err := elf.NewFile(io.NewSectionReader(r, 0, size))
+	if err != nil {
+		return false
+	}
+
+	return f.Type == elf.ET_CORE
+}
+
+// scanCore searches the memory saved in the PT_LOAD segments of the ELF core
+// file in the first size bytes of r, using physical addresses as offsets.
+func scanCore(r io.ReaderAt, size, align int64, opts *ScanOptions) ([]*ScanResult, error) {
+	f, err := elf.NewFile(io.NewSectionReader(r, 0, size))
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := newPhysMemory(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// Memory which is not saved in the file reads as zero and holds no
+	// anchors, so only the saved part of each segment is searched.
+	var offs []int64
+	for _, s := range m.segs {
+		soffs, err := scanAnchors(io.NewSectionReader(m, s.addr, s.filesz), s.filesz, align)
+		if err != nil {
+			return nil, err
+		}
+		for _, off := range soffs {
+			offs = append(offs, s.addr+off)
+		}
+	}
+
+	last := m.segs[len(m.segs)-1]
+	copts := *opts
+	copts.Base = 0
+
+	return scanEntryPoints(m, last.addr+last.memsz, offs, &copts)
+}
+
+// scanEntryPoints decodes the table of every valid entry point at offs in
+// the first size bytes of r.
+func scanEntryPoints(r io.ReaderAt, size int64, offs []int64, opts *ScanOptions) ([]*ScanResult, error) {
+	var (
+		rs []*ScanResult
+		// Offsets of the intermediate anchors of valid 32-bit entry points,
+		// which also parse as legacy DMI entry points.
+		inner = make(map[int64]bool)
+	)
+
+	for _, off := range offs {
+		if inner[off] {
+			continue
+		}
+
+		ep, err := ParseEntryPoint(io.NewSectionReader(r, off, size-off))
+		if err != nil {
+			// Invalid checksum or a chance match of an anchor.
+			continue
+		}
+		if _, ok := ep.(*EntryPoint32Bit); ok {
+			inner[off+16] = true
+		}
+
+		ss, warnings, err := scanTable(r, size, ep, opts)
+		if err != nil {
+			continue
+		}
+
+		rs = append(rs, &ScanResult{
+			Offset:     off,
+			EntryPoint: ep,
+			Structures: ss,
+			Warnings:   warnings,
+		})
+	}
+
+	if len(rs) == 0 {
+		return nil, errors.New("no SMBIOS table found in image")
+	}
+
+	return rs, nil
+}
+
+// scanAnchors returns the offsets of the entry point anchors which start at
+// a multiple of align in the first size bytes of r.
+func scanAnchors(r io.ReaderAt, size, align int64) ([]int64, error) {
+	// Chunks overlap by the length of the longest anchor so that an
+	// anchor crossing a chunk boundary is found in the next chunk.
+	overlap := int64(len(magicDMI))
+	chunk := scanChunk - scanChunk%align
+	if chunk < align {
+		chunk = align
+	}
+
+	var (
+		offs []int64
+		b    = make([]byte, chunk+overlap)
+	)
+
+	for start := int64(0); start < size; start += chunk {
+		n := int64(len(b))
+		if start+n > size {
+			n = size - start
+		}
+
+		// Only the bytes read are searched, the rest of b holds the
+		// previous chunk.
+		rn, err := r.ReadAt(b[:n], start)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		n = int64(rn)
+
+		for i := int64(0); i < chunk && i < n; i += align {
+			p := b[i:n]
+			if bytes.HasPrefix(p, magicPrefix) || bytes.HasPrefix(p, magicDMI) {
+				offs = append(offs, start+i)
+			}
+		}
+	}
+
+	return offs, nil
+}
+
+// scanTable decodes the table of ep from the first size bytes of r.
+func scanTable(r io.ReaderAt, size int64, ep EntryPoint, opts *ScanOptions) ([]*Structure, []error, error) {
+	addr, tsize := ep.Table()
+	off := int64(addr) - opts.Base
+	if off < 0 || off >= size {
+		return nil, nil, fmt.Errorf("SMBIOS table address %#x is outside of the image", addr)
+	}
+
+	// The size of a 3.x table is only a maximum, so it may run past the
+	// end of the image.
+	end := off + int64(tsize)
+	if end > size {
+		if _, ok := ep.(*EntryPoint64Bit); !ok {
+			return nil, nil, fmt.Errorf("SMBIOS table of %d bytes at %#x exceeds the image", tsize, addr)
+		}
+		end = size
+	}
+
+	dopts := opts.Decode
+	if dopts == nil {
+		dopts = EntryPointDecodeOptions(ep)
+	}
+
+	d := NewDecoder(io.NewSectionReader(r, off, end-off))
+	return d.DecodeWith(dopts)
+}
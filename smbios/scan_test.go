@@ -0,0 +1,184 @@
+package smbios
+
+import (
+	"bytes"
+	"debug/elf"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScanImage(t *testing.T) {
+	ss := []*Structure{
+		{
+			Header:    Header{Type: 1, Length: 5, Handle: 1},
+			Formatted: []byte{0x01},
+			Strings:   []string{"Dell Inc."},
+		},
+		{
+			Header: Header{Type: 127, Length: 4, Handle: 2},
+		},
+	}
+	table := encodeStructures(ss)
+
+	b := make([]byte, 0x20000)
+
+	// A dump at offset 0, whose 32-bit entry point points at offset 32.
+	var dump bytes.Buffer
+	if err := WriteDump(&dump, &EntryPoint32Bit{Major: 2, Minor: 8}, ss); err != nil {
+		t.Fatalf("failed to write dump: %v", err)
+	}
+	copy(b, dump.Bytes())
+
+	// A 3.x entry point at the end of the first chunk, with its table
+	// padded to the maximum size.
+	copy(b[0xfff0:], mustMarshalEntryPoint(&EntryPoint64Bit{
+		Major:                 3,
+		Minor:                 3,
+		StructureTableMaxSize: 0x100,
+		StructureTableAddress: 0x12000,
+	}))
+	copy(b[0x12000:], table)
+
+	// A legacy DMI entry point.
+	copy(b[0x11000:], mustMarshalEntryPoint(&LegacyDMIEntryPoint{
+		StructureTableLength:  uint16(len(table)),
+		StructureTableAddress: 0x13000,
+		NumberStructures:      uint16(len(ss)),
+		BCDRevision:           0x20,
+	}))
+	copy(b[0x13000:], table)
+
+	// A 3.x entry point with an invalid checksum.
+	bad := mustMarshalEntryPoint(&EntryPoint64Bit{StructureTableAddress: 0x12000})
+	bad[chkIndex64]++
+	copy(b[0x11100:], bad)
+
+	// A 3.x entry point whose table is outside of the image.
+	copy(b[0x11200:], mustMarshalEntryPoint(&EntryPoint64Bit{StructureTableAddress: 0x40000}))
+
+	// A legacy DMI entry point which is not paragraph aligned.
+	copy(b[0x11301:], mustMarshalEntryPoint(&LegacyDMIEntryPoint{
+		StructureTableLength:  uint16(len(table)),
+		StructureTableAddress: 0x13000,
+		NumberStructures:      uint16(len(ss)),
+	}))
+
+	tests := []struct {
+		name string
+		opts *ScanOptions
+		offs []int64
+	}{
+		{
+			name: "default",
+			offs: []int64{0x0, 0xfff0, 0x11000},
+		},
+		{
+			name: "unaligned",
+			opts: &ScanOptions{Align: 1},
+			offs: []int64{0x0, 0xfff0, 0x11000, 0x11301},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, err := ScanImage(bytes.NewReader(b), int64(len(b)), tt.opts)
+			if err != nil {
+				t.Fatalf("failed to scan image: %v", err)
+			}
+
+			var offs []int64
+			for _, r := range rs {
+				offs = append(offs, r.Offset)
+
+				if diff := cmp.Diff(ss, r.Structures); diff != "" {
+					t.Fatalf("unexpected structures at %#x (-want +got):\n%s", r.Offset, diff)
+				}
+				if len(r.Warnings) > 0 {
+					t.Fatalf("unexpected warnings at %#x: %v", r.Offset, r.Warnings)
+				}
+			}
+
+			if diff := cmp.Diff(tt.offs, offs); diff != "" {
+				t.Fatalf("unexpected entry point offsets (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScanImageBase(t *testing.T) {
+	ss := []*Structure{{Header: Header{Type: 127, Length: 4}}}
+	table := encodeStructures(ss)
+
+	// A firmware image mapped at 0xfff00000.
+	const base = 0xfff00000
+	b := make([]byte, 0x1000)
+	copy(b[0x100:], mustMarshalEntryPoint(&EntryPoint64Bit{
+		StructureTableMaxSize: uint32(len(table)),
+		StructureTableAddress: base + 0x800,
+	}))
+	copy(b[0x800:], table)
+
+	if _, err := ScanImage(bytes.NewReader(b), int64(len(b)), nil); err == nil {
+		t.Fatal("expected an error without a base, but none occurred")
+	}
+
+	rs, err := ScanImage(bytes.NewReader(b), int64(len(b)), &ScanOptions{Base: base})
+	if err != nil {
+		t.Fatalf("failed to scan image: %v", err)
+	}
+	if len(rs) != 1 || rs[0].Offset != 0x100 {
+		t.Fatalf("unexpected results: %v", rs)
+	}
+}
+
+func TestScanImageCore(t *testing.T) {
+	ss := []*Structure{{Header: Header{Type: 127, Length: 4, Handle: 1}}}
+	table := encodeStructures(ss)
+
+	// An entry point and its table saved in the second segment of a core
+	// file, which is only found through physical addresses.
+	high := make([]byte, 0x200)
+	copy(high[0x40:], mustMarshalEntryPoint(&EntryPoint64Bit{
+		Major:                 3,
+		StructureTableMaxSize: 0x1000,
+		StructureTableAddress: 0x7f000100,
+	}))
+	copy(high[0x100:], table)
+
+	b, err := ioutil.ReadFile(writeCore(t, elf.ET_CORE, []coreSegment{
+		{addr: startAddr, memsz: 0x10000},
+		{addr: 0x7f000000, memsz: 0x2000, b: high},
+	}))
+	if err != nil {
+		t.Fatalf("failed to read core file: %v", err)
+	}
+
+	rs, err := ScanImage(bytes.NewReader(b), int64(len(b)), nil)
+	if err != nil {
+		t.Fatalf("failed to scan core file: %v", err)
+	}
+	if len(rs) != 1 || rs[0].Offset != 0x7f000040 {
+		t.Fatalf("unexpected results: %v", rs)
+	}
+	if diff := cmp.Diff(ss, rs[0].Structures); diff != "" {
+		t.Fatalf("unexpected structures (-want +got):\n%s", diff)
+	}
+}
+
+func TestScanAnchorsShortRead(t *testing.T) {
+	// The image is shorter than the size given, so the last read returns
+	// fewer bytes than asked for and the rest of the buffer still holds the
+	// anchor from the first chunk.
+	b := make([]byte, scanChunk+32)
+	copy(b[64:], magic64)
+
+	offs, err := scanAnchors(bytes.NewReader(b), 2*scanChunk, 16)
+	if err != nil {
+		t.Fatalf("failed to scan anchors: %v", err)
+	}
+	if diff := cmp.Diff([]int64{64}, offs); diff != "" {
+		t.Fatalf("unexpected anchor offsets (-want +got):\n%s", diff)
+	}
+}
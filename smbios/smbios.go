@@ -3,6 +3,8 @@ package smbios
 import (
 	"fmt"
 	"log"
+	"os"
+	"reflect"
 )
 
 type SMBIOS struct {
@@ -14,79 +16,190 @@ type SMBIOS struct {
 	BaseboardInformations []*BaseboardInformation  // type 2
 	SystemEnclosures      []*SystemEnclosure       // type 3
 	ProcessorInformations []*ProcessorInformation  // type 4
+	MemoryControllers     []*MemoryController      // type 5
+	MemoryModules         []*MemoryModule          // type 6
 	MemoryDevices         []*MemoryDeviceStructure // type 17
+	OutOfBandRemoteAccess *OutOfBandRemoteAccess   // type 30
+	BootIntegrityServices *BootIntegrityServices   // type 31
+	ManagementDevices     []*ManagementDevice      // type 34, 35, 36
+	MemoryChannels        []*MemoryChannel         // type 37
+	AdditionalInformation []*AdditionalInformation // type 40
+	// Parsed 按类型保存所有注册了解析器的 structure 的解析结果，包括通过
+	// RegisterParser 注册的 OEM 类型
+	Parsed map[uint8][]interface{}
+	// Structures 与 Parsed 一一对应，保存每个解析结果的原始 structure，
+	// 其中已经挂上了引用它的 type 40 附加信息，通过 Source 和 AdditionalAt 查询
+	Structures map[uint8][]*Structure
+	// Unparsed 保存没有注册解析器的原始 structure
+	Unparsed []*Structure
+	// Partial 为 true 表示数据不是从 SMBIOS 表解析的，而是由 ReadDMIID 从
+	// 操作系统导出的部分字段构造的，版本号和大部分字段都没有值
+	Partial bool
 }
 
 func GetSMBIOS() *SMBIOS {
+	return GetSMBIOSWithOptions(nil)
+}
+
+// GetSMBIOSWithOptions 与 GetSMBIOS 相同，但按 opts 查找 SMBIOS 表和
+// DMIIDRoot，nil 与零值相同
+func GetSMBIOSWithOptions(opts *StreamOptions) *SMBIOS {
 	// Find SMBIOS data in operating system-specific location.
-	rc, ep, err := Stream()
+	rc, ep, err := StreamWithOptions(opts)
 	if err != nil {
+		// Without permission to read the table, fall back to the partial
+		// information which the operating system exports to everyone.
+		if os.IsPermission(err) {
+			if ret, derr := ReadDMIIDFS(opts.fsys(), fsPath(DMIIDRoot)); derr == nil {
+				return ret
+			}
+		}
 		log.Fatalf("failed to open stream: %v", err)
 	}
 	// Be sure to close the stream!
 	defer rc.Close()
 
-	// Decode SMBIOS structures from the stream.
+	// Decode SMBIOS structures from the stream, within the limits of the
+	// entry point.
 	d := NewDecoder(rc)
-	ss, err := d.Decode()
+	ss, warnings, err := d.DecodeWith(EntryPointDecodeOptions(ep))
 	if err != nil {
 		log.Fatalf("failed to decode structures: %v", err)
 	}
+	for _, w := range warnings {
+		log.Printf("decoding structures: %v", w)
+	}
 
-	major, minor, rev := ep.Version()
-	ret := &SMBIOS{}
-	ret.Major = major
-	ret.Minor = minor
-	ret.Revision = rev
+	return ParseSMBIOS(ep, ss)
+}
 
-	for _, s := range ss {
-		// Code based on: https://www.dmtf.org/sites/default/files/standards/documents/DSP0134_3.1.1.pdf.
+// ParseSMBIOS 使用注册的解析器解析 ss，ep 用于获取 SMBIOS 版本，可以为 nil
+func ParseSMBIOS(ep EntryPoint, ss []*Structure) *SMBIOS {
+	ret := &SMBIOS{
+		Parsed:     make(map[uint8][]interface{}),
+		Structures: make(map[uint8][]*Structure),
+	}
+	var (
+		components []*ManagementDeviceComponent
+		thresholds []*ManagementDeviceThresholdData
+	)
+	if ep != nil {
+		ret.Major, ret.Minor, ret.Revision = ep.Version()
+	}
 
-		if s.Header.Type == 0 {
-			out, err := ParseBIOSInformation(s)
-			if err != nil {
-				fmt.Print(err)
+	// 先解析 type 40，把附加信息挂到被引用的 structure 上，再解析其他类型，
+	// 这样每个解析结果都能通过 Source 拿到附加信息
+	type result struct {
+		out interface{}
+		err error
+	}
+	additional := make(map[int]result)
+	var entries []*AdditionalInformationEntry
+	if fn, ok := lookupParser(40); ok {
+		for i, s := range ss {
+			if s.Header.Type != 40 {
+				continue
 			}
-			ret.BIOSInformation = out
-		}
-		if s.Header.Type == 1 {
-			out, err := ParseSystemInformation(s)
-			if err != nil {
-				fmt.Print(err)
+			out, err := fn(s)
+			additional[i] = result{out, err}
+			if ai, ok := out.(*AdditionalInformation); ok && err == nil {
+				entries = append(entries, ai.Entries...)
 			}
-			ret.SystemInformation = out
 		}
-		if s.Header.Type == 2 {
-			out, err := ParseBaseboardInformation(s)
-			if err != nil {
-				fmt.Print(err)
-			}
-			ret.BaseboardInformations = append(ret.BaseboardInformations, out)
+	}
+	if len(entries) > 0 {
+		var errs []error
+		ss, errs = ApplyAdditionalInformation(ss, entries)
+		for _, err := range errs {
+			log.Printf("applying additional information: %v", err)
 		}
-		if s.Header.Type == 3 {
-			out, err := ParseSystemEnclosure(s)
-			if err != nil {
-				fmt.Print(err)
-			}
+	}
 
-			ret.SystemEnclosures = append(ret.SystemEnclosures, out)
+	for i, s := range ss {
+		// Code based on: https://www.dmtf.org/sites/default/files/standards/documents/DSP0134_3.1.1.pdf.
+		fn, ok := lookupParser(s.Header.Type)
+		if !ok {
+			ret.Unparsed = append(ret.Unparsed, s)
+			continue
 		}
-		if s.Header.Type == 4 {
-			out, err := ParseProcessorInformation(s)
-			if err != nil {
-				fmt.Print(err)
-			}
-			ret.ProcessorInformations = append(ret.ProcessorInformations, out)
+
+		r, ok := additional[i]
+		if !ok {
+			r.out, r.err = fn(s)
+		}
+		out, err := r.out, r.err
+		if err != nil {
+			fmt.Print(err)
+			continue
 		}
+		ret.Parsed[s.Header.Type] = append(ret.Parsed[s.Header.Type], out)
+		ret.Structures[s.Header.Type] = append(ret.Structures[s.Header.Type], s)
 
-		if s.Header.Type == 17 {
-			out, err := ParseMemoryDevice(s)
-			if err != nil {
-				fmt.Print(err)
-			}
+		switch out := out.(type) {
+		case *BIOSInformation:
+			ret.BIOSInformation = out
+		case *SystemInformation:
+			ret.SystemInformation = out
+		case *BaseboardInformation:
+			ret.BaseboardInformations = append(ret.BaseboardInformations, out)
+		case *SystemEnclosure:
+			ret.SystemEnclosures = append(ret.SystemEnclosures, out)
+		case *ProcessorInformation:
+			ret.ProcessorInformations = append(ret.ProcessorInformations, out)
+		case *MemoryController:
+			ret.MemoryControllers = append(ret.MemoryControllers, out)
+		case *MemoryModule:
+			ret.MemoryModules = append(ret.MemoryModules, out)
+		case *MemoryDeviceStructure:
 			ret.MemoryDevices = append(ret.MemoryDevices, out)
+		case *OutOfBandRemoteAccess:
+			ret.OutOfBandRemoteAccess = out
+		case *BootIntegrityServices:
+			ret.BootIntegrityServices = out
+		case *ManagementDevice:
+			ret.ManagementDevices = append(ret.ManagementDevices, out)
+		case *ManagementDeviceComponent:
+			components = append(components, out)
+		case *ManagementDeviceThresholdData:
+			thresholds = append(thresholds, out)
+		case *MemoryChannel:
+			ret.MemoryChannels = append(ret.MemoryChannels, out)
+		case *AdditionalInformation:
+			ret.AdditionalInformation = append(ret.AdditionalInformation, out)
 		}
+	}
 
+	LinkMemoryModules(ret.MemoryControllers, ret.MemoryModules)
+	LinkMemoryChannels(ret.MemoryChannels, ret.MemoryDevices)
+
+	for _, err := range LinkManagementDevices(ret.ManagementDevices, components, thresholds, ss) {
+		log.Printf("linking management devices: %v", err)
 	}
 	return ret
 }
+
+// Source 返回解析结果 v 对应的原始 structure，v 必须是 Parsed 中的值，
+// 例如 s.BIOSInformation，找不到时返回 nil
+func (s *SMBIOS) Source(v interface{}) *Structure {
+	if v == nil || !reflect.TypeOf(v).Comparable() {
+		return nil
+	}
+	for t, outs := range s.Parsed {
+		for i, out := range outs {
+			if out == v && i < len(s.Structures[t]) {
+				return s.Structures[t][i]
+			}
+		}
+	}
+	return nil
+}
+
+// AdditionalAt 返回 type 40 给解析结果 v 的 formatted 区域偏移 off 处字段补充的
+// 附加信息，off 与 Structure.AdditionalAt 相同，不包含 header
+func (s *SMBIOS) AdditionalAt(v interface{}, off int) []*AdditionalInformationEntry {
+	src := s.Source(v)
+	if src == nil {
+		return nil
+	}
+	return src.AdditionalAt(off)
+}
@@ -17,8 +17,9 @@
 package smbios
 
 import (
+	"errors"
 	"io"
-	"os"
+	"io/fs"
 )
 
 const (
@@ -28,15 +29,15 @@ const (
 )
 
 // stream opens the SMBIOS entry point and an SMBIOS structure stream.
-func stream() (io.ReadCloser, EntryPoint, error) {
+func stream(fsys fs.FS) (io.ReadCloser, EntryPoint, error) {
 	// First, check for the sysfs location present in modern kernels.
-	_, err := os.Stat(sysfsEntryPoint)
+	_, err := fs.Stat(fsys, fsPath(sysfsEntryPoint))
 	switch {
 	case err == nil:
-		return sysfsStream(sysfsEntryPoint, sysfsDMI)
-	case os.IsNotExist(err):
+		return sysfsStream(fsys, sysfsEntryPoint, sysfsDMI)
+	case errors.Is(err, fs.ErrNotExist):
 		// Fall back to the standard UNIX-like system method.
-		return devMemStream()
+		return devMemStream(fsys)
 	default:
 		return nil, nil, err
 	}
@@ -44,8 +45,8 @@ func stream() (io.ReadCloser, EntryPoint, error) {
 
 // sysfsStream reads the SMBIOS entry point and structure stream from
 // two files; usually the modern sysfs locations.
-func sysfsStream(entryPoint, dmi string) (io.ReadCloser, EntryPoint, error) {
-	epf, err := os.Open(entryPoint)
+func sysfsStream(fsys fs.FS, entryPoint, dmi string) (io.ReadCloser, EntryPoint, error) {
+	epf, err := fsys.Open(fsPath(entryPoint))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -56,7 +57,7 @@ func sysfsStream(entryPoint, dmi string) (io.ReadCloser, EntryPoint, error) {
 		return nil, nil, err
 	}
 
-	sf, err := os.Open(dmi)
+	sf, err := fsys.Open(fsPath(dmi))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,126 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//+build linux
+
+package smbios
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_streamSysfs(t *testing.T) {
+	stream := []byte{
+		0x00, 0x05, 0x01, 0x00,
+		0xff,
+		0x00,
+		0x00,
+
+		127, 0x04, 0x02, 0x00,
+		0x00,
+		0x00,
+	}
+	epb := mustMarshalEntryPoint(&EntryPoint64Bit{
+		Major:                 3,
+		StructureTableMaxSize: uint32(len(stream)),
+	})
+
+	fsys := fstest.MapFS{
+		"sys/firmware/dmi/tables/smbios_entry_point": &fstest.MapFile{Data: epb},
+		"sys/firmware/dmi/tables/DMI":                &fstest.MapFile{Data: stream},
+	}
+
+	rc, ep, err := StreamWithOptions(&StreamOptions{FS: fsys})
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	defer rc.Close()
+
+	if major, _, _ := ep.Version(); major != 3 {
+		t.Fatalf("unexpected SMBIOS major version: %d", major)
+	}
+
+	ss, err := NewDecoder(rc).Decode()
+	if err != nil {
+		t.Fatalf("failed to decode structures: %v", err)
+	}
+
+	want := []*Structure{
+		{
+			Header:    Header{Type: 0, Length: 5, Handle: 1},
+			Formatted: []byte{0xff},
+		},
+		{
+			Header: Header{Type: 127, Length: 4, Handle: 2},
+		},
+	}
+	if diff := cmp.Diff(want, ss); diff != "" {
+		t.Fatalf("unexpected structures (-want +got):\n%s", diff)
+	}
+}
+
+func Test_streamRoot(t *testing.T) {
+	// A host filesystem mounted elsewhere, as in a container.
+	dir, err := ioutil.TempDir("", "smbios")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tables := filepath.Join(dir, "sys/firmware/dmi/tables")
+	if err := os.MkdirAll(tables, 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	stream := []byte{
+		127, 0x04, 0x01, 0x00,
+		0x00,
+		0x00,
+	}
+	epb := mustMarshalEntryPoint(&EntryPoint64Bit{StructureTableMaxSize: uint32(len(stream))})
+
+	if err := ioutil.WriteFile(filepath.Join(tables, "smbios_entry_point"), epb, 0644); err != nil {
+		t.Fatalf("failed to write entry point: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(tables, "DMI"), stream, 0644); err != nil {
+		t.Fatalf("failed to write table: %v", err)
+	}
+
+	rc, _, err := StreamWithOptions(&StreamOptions{Root: dir})
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	defer rc.Close()
+
+	ss, err := NewDecoder(rc).Decode()
+	if err != nil {
+		t.Fatalf("failed to decode structures: %v", err)
+	}
+	if l := len(ss); l != 1 {
+		t.Fatalf("unexpected number of structures: %d", l)
+	}
+}
+
+func Test_streamNotFound(t *testing.T) {
+	// Neither sysfs nor /dev/mem exist.
+	if _, _, err := StreamWithOptions(&StreamOptions{FS: fstest.MapFS{}}); err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+}
@@ -15,11 +15,15 @@
 package smbios
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"io/ioutil"
-	"os"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -33,6 +37,15 @@ const (
 	endAddr   = 0x000fffff
 )
 
+// efiSystabs are the locations of the EFI system table, which records the
+// address of the SMBIOS entry point on UEFI systems, where it need not be
+// in the legacy BIOS memory range.  The first is used by modern kernels,
+// the second by older kernels.
+var efiSystabs = []string{
+	"/sys/firmware/efi/systab",
+	"/proc/efi/systab",
+}
+
 // memoryStream reads the SMBIOS entry point and structure stream from
 // an io.ReadSeeker (usually system memory).
 //
@@ -44,8 +57,14 @@ func memoryStream(rs io.ReadSeeker, startAddr, endAddr int) (io.ReadCloser, Entr
 		return nil, nil, err
 	}
 
-	// Found it; seek to the location of the entry point.
-	if _, err := rs.Seek(int64(addr), io.SeekStart); err != nil {
+	return memoryStreamAt(rs, int64(addr))
+}
+
+// memoryStreamAt reads the SMBIOS entry point located at addr and the
+// structure stream it points to from an io.ReadSeeker.
+func memoryStreamAt(rs io.ReadSeeker, addr int64) (io.ReadCloser, EntryPoint, error) {
+	// Seek to the location of the entry point.
+	if _, err := rs.Seek(addr, io.SeekStart); err != nil {
 		return nil, nil, err
 	}
 
@@ -87,6 +106,12 @@ func findEntryPoint(rs io.ReadSeeker, start, end int) (int, error) {
 	var (
 		addr  int
 		found bool
+
+		// A legacy DMI entry point is only used when no SMBIOS entry
+		// point is present.  The intermediate anchor of a 32-bit entry
+		// point is never seen here, since the outer anchor precedes it.
+		dmiAddr  int
+		dmiFound bool
 	)
 
 	for addr = start; addr < end; addr += paragraph {
@@ -99,8 +124,15 @@ func findEntryPoint(rs io.ReadSeeker, start, end int) (int, error) {
 			found = true
 			break
 		}
+
+		if !dmiFound && bytes.HasPrefix(b, magicDMI) {
+			dmiAddr, dmiFound = addr, true
+		}
 	}
 
+	if !found && dmiFound {
+		return dmiAddr, nil
+	}
 	if !found {
 		return 0, errors.New("no SMBIOS entry point found in memory")
 	}
@@ -109,17 +141,110 @@ func findEntryPoint(rs io.ReadSeeker, start, end int) (int, error) {
 	return addr, nil
 }
 
+// efiEntryPoint returns the address of the SMBIOS entry point recorded in
+// the first EFI system table in fsys found in systabs.  found is false if
+// none of the files exist or none records an SMBIOS entry point.
+func efiEntryPoint(fsys fs.FS, systabs []string) (addr int64, found bool, err error) {
+	for _, p := range systabs {
+		f, err := fsys.Open(fsPath(p))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return 0, false, err
+		}
+
+		addr, found, err = parseSystab(f)
+		_ = f.Close()
+		if err != nil || found {
+			return addr, found, err
+		}
+	}
+
+	return 0, false, nil
+}
+
+// parseSystab parses the "KEY=0xADDRESS" lines of an EFI system table file,
+// returning the address of the SMBIOS entry point.  The 64-bit entry point
+// from an SMBIOS3 line is preferred over the 32-bit one from an SMBIOS line.
+func parseSystab(r io.Reader) (addr int64, found bool, err error) {
+	var (
+		smbios, smbios3 int64
+		ok, ok3         bool
+	)
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		kv := strings.SplitN(strings.TrimSpace(s.Text()), "=", 2)
+		if len(kv) != 2 || (kv[0] != "SMBIOS" && kv[0] != "SMBIOS3") {
+			continue
+		}
+
+		v, err := strconv.ParseInt(kv[1], 0, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %s address in EFI system table: %q", kv[0], kv[1])
+		}
+
+		if kv[0] == "SMBIOS3" {
+			smbios3, ok3 = v, true
+		} else {
+			smbios, ok = v, true
+		}
+	}
+	if err := s.Err(); err != nil {
+		return 0, false, err
+	}
+
+	switch {
+	case ok3:
+		return smbios3, true, nil
+	case ok:
+		return smbios, true, nil
+	default:
+		return 0, false, nil
+	}
+}
+
 // devMemStream reads the SMBIOS entry point and structure stream from
 // the UNIX-like system /dev/mem device.
 //
 // This is UNIX-like system specific, but since it doesn't employ any system
 // calls or OS-dependent constants, it remains in this file for simplicity.
-func devMemStream() (io.ReadCloser, EntryPoint, error) {
-	mem, err := os.Open(devMem)
+func devMemStream(fsys fs.FS) (io.ReadCloser, EntryPoint, error) {
+	return memStream(fsys, devMem, efiSystabs)
+}
+
+// memStream reads the SMBIOS entry point and structure stream from the
+// memory device mem in fsys, using the address from an EFI system table in
+// systabs when available and scanning the legacy BIOS memory range
+// otherwise, or when that address cannot be read.
+//
+// memStream is an entry point for tests.
+func memStream(fsys fs.FS, mem string, systabs []string) (io.ReadCloser, EntryPoint, error) {
+	addr, found, efiErr := efiEntryPoint(fsys, systabs)
+
+	f, err := fsys.Open(fsPath(mem))
 	if err != nil {
 		return nil, nil, err
 	}
-	defer mem.Close()
+	defer f.Close()
+
+	rs, ok := f.(io.ReadSeeker)
+	if !ok {
+		return nil, nil, fmt.Errorf("memory device %s does not support seeking", mem)
+	}
 
-	return memoryStream(mem, startAddr, endAddr)
+	if efiErr == nil && found {
+		rc, ep, err := memoryStreamAt(rs, addr)
+		if err == nil {
+			return rc, ep, nil
+		}
+		efiErr = fmt.Errorf("failed to read entry point at EFI address %#x: %v", addr, err)
+	}
+
+	rc, ep, err := memoryStream(rs, startAddr, endAddr)
+	if err != nil && efiErr != nil {
+		return nil, nil, fmt.Errorf("%v; scanning legacy BIOS memory: %w", efiErr, err)
+	}
+	return rc, ep, err
 }
@@ -18,8 +18,11 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io/ioutil"
 	"math"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -123,6 +126,52 @@ func Test_memoryStream(t *testing.T) {
 			},
 			ok: true,
 		},
+		{
+			name: "legacy DMI, OK",
+			b: func() []byte {
+				const addr = 0x00f0
+				stream := []byte{
+					0x00, 0x05, 0x01, 0x00,
+					0xff,
+					0x00,
+					0x00,
+
+					127, 0x04, 0x02, 0x00,
+					0x00,
+					0x00,
+				}
+
+				epb := mustMarshalEntryPoint(&LegacyDMIEntryPoint{
+					StructureTableLength:  uint16(len(stream)),
+					StructureTableAddress: addr,
+					NumberStructures:      2,
+					BCDRevision:           0x20,
+				})
+
+				b := makeMemory(nil, epb, nil)
+				copy(b[addr:], stream)
+
+				return b
+			}(),
+			ss: []*Structure{
+				{
+					Header: Header{
+						Type:   0,
+						Length: 5,
+						Handle: 1,
+					},
+					Formatted: []byte{0xff},
+				},
+				{
+					Header: Header{
+						Type:   127,
+						Length: 4,
+						Handle: 2,
+					},
+				},
+			},
+			ok: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -177,6 +226,8 @@ func mustMarshalEntryPoint(ep EntryPoint) []byte {
 	switch x := ep.(type) {
 	case *EntryPoint64Bit:
 		return marshal64(x)
+	case *LegacyDMIEntryPoint:
+		return marshalDMI(x)
 	default:
 		// TODO(mdlayher): expand with 32-bit entry point.
 		panic(fmt.Sprintf("entry point marshaling not implemented for %T", ep))
@@ -212,3 +263,235 @@ func marshal64(ep *EntryPoint64Bit) []byte {
 
 	return b
 }
+
+func marshalDMI(ep *LegacyDMIEntryPoint) []byte {
+	b := make([]byte, expLenDMI)
+
+	copy(b[0:5], magicDMI)
+	binary.LittleEndian.PutUint16(b[6:8], ep.StructureTableLength)
+	binary.LittleEndian.PutUint32(b[8:12], ep.StructureTableAddress)
+	binary.LittleEndian.PutUint16(b[12:14], ep.NumberStructures)
+	b[14] = ep.BCDRevision
+
+	var chk uint8
+	for i := range b {
+		chk += b[i]
+	}
+
+	// Produce the correct checksum for the entry point.
+	b[chkIndexDMI] = uint8(256 - int(chk))
+
+	return b
+}
+
+func Test_findEntryPointLegacyDMI(t *testing.T) {
+	dmi := mustMarshalEntryPoint(&LegacyDMIEntryPoint{BCDRevision: 0x20})
+	sm3 := mustMarshalEntryPoint(&EntryPoint64Bit{})
+
+	tests := []struct {
+		name string
+		b    []byte
+		addr int
+	}{
+		{
+			name: "legacy DMI only",
+			b:    makeMemory(nil, append(make([]byte, 0x20), dmi...), nil),
+			addr: start + 0x20,
+		},
+		{
+			name: "SMBIOS entry point preferred",
+			b: makeMemory(nil, append(append(append(make([]byte, 0x20), dmi...),
+				make([]byte, 0x11)...), sm3...), nil),
+			addr: start + 0x40,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := findEntryPoint(bytes.NewReader(tt.b), start, end)
+			if err != nil {
+				t.Fatalf("failed to find entry point: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.addr, addr); diff != "" {
+				t.Fatalf("unexpected entry point address (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_memStreamEFISystab(t *testing.T) {
+	// Memory with a 64-bit entry point at 0x200 and a legacy one at 0x400,
+	// both pointing at the same table at 0x800.  Neither is in the legacy
+	// BIOS memory range.
+	stream := []byte{
+		0x00, 0x05, 0x01, 0x00,
+		0xff,
+		0x00,
+		0x00,
+
+		127, 0x04, 0x02, 0x00,
+		0x00,
+		0x00,
+	}
+
+	mem := make([]byte, 0x1000)
+	copy(mem[0x200:], mustMarshalEntryPoint(&EntryPoint64Bit{
+		StructureTableMaxSize: uint32(len(stream)),
+		StructureTableAddress: 0x800,
+	}))
+	copy(mem[0x400:], mustMarshalEntryPoint(&LegacyDMIEntryPoint{
+		StructureTableLength:  uint16(len(stream)),
+		StructureTableAddress: 0x800,
+		NumberStructures:      2,
+	}))
+	copy(mem[0x800:], stream)
+
+	systab := func(name string) *fstest.MapFile {
+		b, err := ioutil.ReadFile(filepath.Join("testdata/efi", name))
+		if err != nil {
+			t.Fatalf("failed to read fixture: %v", err)
+		}
+		return &fstest.MapFile{Data: b}
+	}
+
+	tests := []struct {
+		name string
+		fs   fstest.MapFS
+		ep   EntryPoint
+		ok   bool
+	}{
+		{
+			name: "SMBIOS3 preferred",
+			fs: fstest.MapFS{
+				"sys/firmware/efi/systab": systab("systab"),
+				"proc/efi/systab":         systab("systab-legacy"),
+			},
+			ep: &EntryPoint64Bit{},
+			ok: true,
+		},
+		{
+			name: "legacy location",
+			fs: fstest.MapFS{
+				"proc/efi/systab": systab("systab-legacy"),
+			},
+			ep: &LegacyDMIEntryPoint{},
+			ok: true,
+		},
+		{
+			name: "invalid address",
+			fs: fstest.MapFS{
+				"sys/firmware/efi/systab": systab("systab-invalid"),
+			},
+		},
+		{
+			// With no SMBIOS lines, the legacy BIOS memory range is scanned,
+			// which is beyond the end of this memory.
+			name: "no SMBIOS address",
+			fs: fstest.MapFS{
+				"sys/firmware/efi/systab": systab("systab-none"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fs["dev/mem"] = &fstest.MapFile{Data: mem}
+
+			rc, ep, err := devMemStream(tt.fs)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+			defer rc.Close()
+
+			if want, got := fmt.Sprintf("%T", tt.ep), fmt.Sprintf("%T", ep); want != got {
+				t.Fatalf("unexpected entry point type: want %s, got %s", want, got)
+			}
+
+			ss, err := NewDecoder(rc).Decode()
+			if err != nil {
+				t.Fatalf("failed to decode structures: %v", err)
+			}
+			if l := len(ss); l != 2 {
+				t.Fatalf("unexpected number of structures: %d", l)
+			}
+		})
+	}
+}
+
+func Test_devMemStreamScan(t *testing.T) {
+	// A legacy BIOS memory range with an entry point, and no usable EFI
+	// system table, as found on BIOS systems.
+	const addr = 0x000f0100
+	stream := []byte{
+		127, 0x04, 0x01, 0x00,
+		0x00,
+		0x00,
+	}
+
+	mem := make([]byte, endAddr+1)
+	copy(mem[addr:], mustMarshalEntryPoint(&EntryPoint64Bit{
+		StructureTableMaxSize: uint32(len(stream)),
+		StructureTableAddress: 0x1000,
+	}))
+	copy(mem[0x1000:], stream)
+
+	systab := func(name string) *fstest.MapFile {
+		b, err := ioutil.ReadFile(filepath.Join("testdata/efi", name))
+		if err != nil {
+			t.Fatalf("failed to read fixture: %v", err)
+		}
+		return &fstest.MapFile{Data: b}
+	}
+
+	tests := []struct {
+		name string
+		fs   fstest.MapFS
+	}{
+		{
+			name: "no EFI system table",
+			fs:   fstest.MapFS{},
+		},
+		{
+			name: "invalid EFI address",
+			fs: fstest.MapFS{
+				"sys/firmware/efi/systab": systab("systab-invalid"),
+			},
+		},
+		{
+			name: "no entry point at EFI address",
+			fs: fstest.MapFS{
+				"sys/firmware/efi/systab": systab("systab-legacy"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fs["dev/mem"] = &fstest.MapFile{Data: mem}
+
+			rc, _, err := devMemStream(tt.fs)
+			if err != nil {
+				t.Fatalf("failed to open stream: %v", err)
+			}
+			defer rc.Close()
+
+			ss, err := NewDecoder(rc).Decode()
+			if err != nil {
+				t.Fatalf("failed to decode structures: %v", err)
+			}
+			if l := len(ss); l != 1 {
+				t.Fatalf("unexpected number of structures: %d", l)
+			}
+		})
+	}
+}
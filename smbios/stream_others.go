@@ -19,10 +19,11 @@ package smbios
 import (
 	"fmt"
 	"io"
+	"io/fs"
 	"runtime"
 )
 
 // stream is not implemented for unsupported platforms.
-func stream() (io.ReadCloser, EntryPoint, error) {
+func stream(_ fs.FS) (io.ReadCloser, EntryPoint, error) {
 	return nil, nil, fmt.Errorf("opening SMBIOS stream not implemented on %q", runtime.GOOS)
 }
@@ -21,10 +21,11 @@ package smbios
 
 import (
 	"io"
+	"io/fs"
 )
 
 // stream opens the SMBIOS entry point and an SMBIOS structure stream.
-func stream() (io.ReadCloser, EntryPoint, error) {
+func stream(fsys fs.FS) (io.ReadCloser, EntryPoint, error) {
 	// Use the standard UNIX-like system method.
-	return devMemStream()
+	return devMemStream(fsys)
 }
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"io/ioutil"
 	"syscall"
 	"unsafe"
@@ -106,7 +107,7 @@ func windowsStream(buf []byte) (io.ReadCloser, EntryPoint, error) {
 	return ioutil.NopCloser(bytes.NewReader(tableBuff)), entryPoint, nil
 }
 
-func stream() (io.ReadCloser, EntryPoint, error) {
+func stream(_ fs.FS) (io.ReadCloser, EntryPoint, error) {
 	// Call first with empty buffer to get size.
 	r1, _, err := procGetSystemFirmwareTable.Call(
 		uintptr(firmwareTableProviderSigRSMB), // FirmwareTableProviderSignature = 'RSMB'
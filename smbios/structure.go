@@ -14,6 +14,8 @@
 
 package smbios
 
+import "strings"
+
 // A Header is a Structure's header.
 type Header struct {
 	Type   uint8
@@ -26,4 +28,38 @@ type Structure struct {
 	Header    Header
 	Formatted []byte
 	Strings   []string
+
+	// Additional holds the Type 40 additional information entries which
+	// reference this structure, once attached by ApplyAdditionalInformation,
+	// which ParseSMBIOS does before running the parsers.
+	Additional []*AdditionalInformationEntry
+}
+
+// StringAt returns the string referenced by the string number stored at
+// offset off of the formatted section, which is the specification's offset
+// minus the length of the Header.  An empty string is returned if the offset
+// is out of range or the string number is unset or invalid.
+func (s *Structure) StringAt(off int) string {
+	if off < 0 || off >= len(s.Formatted) {
+		return ""
+	}
+
+	n := int(s.Formatted[off])
+	if n == 0 || n > len(s.Strings) {
+		return ""
+	}
+
+	return strings.TrimSpace(s.Strings[n-1])
+}
+
+// bitStrings returns the names of the bits set in v.  Bits without a name
+// are skipped.
+func bitStrings(v uint64, names []string) []string {
+	var out []string
+	for i := range names {
+		if v&(1<<uint(i)) != 0 {
+			out = append(out, names[i])
+		}
+	}
+	return out
 }
@@ -0,0 +1,80 @@
+package smbios
+
+import (
+	"strconv"
+)
+
+// Bytes is a size in bytes.
+type Bytes uint64
+
+// Binary size units.
+const (
+	KiB Bytes = 1 << (10 * (iota + 1))
+	MiB
+	GiB
+	TiB
+	PiB
+	EiB
+)
+
+// UnknownBytes is reported for sizes the firmware marks as unknown.
+const UnknownBytes = ^Bytes(0)
+
+var byteUnits = []struct {
+	size Bytes
+	name string
+}{
+	{EiB, "EiB"},
+	{PiB, "PiB"},
+	{TiB, "TiB"},
+	{GiB, "GiB"},
+	{MiB, "MiB"},
+	{KiB, "KiB"},
+}
+
+// String formats b in the largest binary unit that represents it exactly,
+// e.g. "64 GiB" or "1536 MiB".
+func (b Bytes) String() string {
+	if b == UnknownBytes {
+		return "Unknown"
+	}
+	for _, u := range byteUnits {
+		if b >= u.size && b%u.size == 0 {
+			return strconv.FormatUint(uint64(b/u.size), 10) + " " + u.name
+		}
+	}
+	return strconv.FormatUint(uint64(b), 10) + " B"
+}
+
+// Megahertz is a clock frequency in MHz, 0 means unknown.
+type Megahertz uint32
+
+// String formats m as e.g. "2400 MHz".
+func (m Megahertz) String() string {
+	if m == 0 {
+		return "Unknown"
+	}
+	return strconv.FormatUint(uint64(m), 10) + " MHz"
+}
+
+// Megatransfers is a transfer rate in MT/s, 0 means unknown.
+type Megatransfers uint32
+
+// String formats m as e.g. "3200 MT/s".
+func (m Megatransfers) String() string {
+	if m == 0 {
+		return "Unknown"
+	}
+	return strconv.FormatUint(uint64(m), 10) + " MT/s"
+}
+
+// Millivolts is a voltage in mV, 0 means unknown.
+type Millivolts uint16
+
+// String formats m in volts, e.g. "1.2 V".
+func (m Millivolts) String() string {
+	if m == 0 {
+		return "Unknown"
+	}
+	return strconv.FormatFloat(float64(m)/1000, 'f', -1, 64) + " V"
+}
@@ -0,0 +1,90 @@
+package smbios_test
+
+import (
+	"testing"
+
+	"github.com/lijingwei9060/go-smbios/smbios"
+)
+
+func TestMemoryDeviceSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     uint16
+		extended uint32
+		want     smbios.Bytes
+		str      string
+	}{
+		{name: "not installed", size: 0x0000, want: 0, str: "0 B"},
+		{name: "unknown", size: 0xFFFF, want: smbios.UnknownBytes, str: "Unknown"},
+		{name: "megabytes", size: 0x4000, want: 16 * smbios.GiB, str: "16 GiB"},
+		{name: "kilobytes", size: 0x8200, want: 512 * smbios.KiB, str: "512 KiB"},
+		{name: "extended", size: 0x7FFF, extended: 0x00010000, want: 64 * smbios.GiB, str: "64 GiB"},
+		{name: "extended reserved bit", size: 0x7FFF, extended: 0x80018000, want: 96 * smbios.GiB, str: "96 GiB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := make([]byte, 36)
+			f[8], f[9] = byte(tt.size), byte(tt.size>>8)
+			f[24], f[25], f[26], f[27] = byte(tt.extended), byte(tt.extended>>8), byte(tt.extended>>16), byte(tt.extended>>24)
+			// 3200 MT/s, 1.2 V configured voltage.
+			f[17], f[18] = 0x80, 0x0C
+			f[34], f[35] = 0xB0, 0x04
+
+			d, err := smbios.ParseMemoryDevice(&smbios.Structure{
+				Header:    smbios.Header{Type: 17, Length: 40},
+				Formatted: f,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if d.Size != tt.want {
+				t.Fatalf("unexpected size: want %d, got %d", tt.want, d.Size)
+			}
+			if s := d.Size.String(); s != tt.str {
+				t.Fatalf("unexpected size string: want %q, got %q", tt.str, s)
+			}
+			if s := d.Speed.String(); s != "3200 MT/s" {
+				t.Fatalf("unexpected speed: %q", s)
+			}
+			if s := d.ConfiguredVoltage.String(); s != "1.2 V" {
+				t.Fatalf("unexpected voltage: %q", s)
+			}
+		})
+	}
+}
+
+func TestBIOSROMSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     byte
+		extended uint16
+		want     string
+	}{
+		{name: "64K units", size: 0x0F, want: "1 MiB"},
+		{name: "extended megabytes", size: 0xFF, extended: 0x0020, want: "32 MiB"},
+		{name: "extended gigabytes", size: 0xFF, extended: 0x4002, want: "2 GiB"},
+		{name: "extended reserved unit", size: 0xFF, extended: 0x8001, want: "Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := make([]byte, 22)
+			f[5] = tt.size
+			f[20], f[21] = byte(tt.extended), byte(tt.extended>>8)
+
+			bios, err := smbios.ParseBIOSInformation(&smbios.Structure{
+				Header:    smbios.Header{Type: 0, Length: 26},
+				Formatted: f,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if s := bios.BIOSROMSize.String(); s != tt.want {
+				t.Fatalf("unexpected BIOS ROM size: want %q, got %q", tt.want, s)
+			}
+		})
+	}
+}
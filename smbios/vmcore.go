@@ -0,0 +1,205 @@
+package smbios
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// VMCoreOptions controls how OpenVMCore locates the SMBIOS entry point.
+type VMCoreOptions struct {
+	// EntryPoint is the physical address of the entry point, such as the
+	// SMBIOS or SMBIOS3 address from the EFI system table of the crashed
+	// system.  If zero, the legacy BIOS memory range is searched.
+	EntryPoint int64
+}
+
+// OpenVMCore reads the SMBIOS entry point and structure stream from the
+// physical memory saved in an ELF64 core file, such as a kdump vmcore,
+// whose PT_LOAD segments record the physical addresses of the memory they
+// hold.  A nil opts is the same as the zero value.
+func OpenVMCore(path string, opts *VMCoreOptions) (io.ReadCloser, EntryPoint, error) {
+	if opts == nil {
+		opts = &VMCoreOptions{}
+	}
+
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	m, err := newPhysMemory(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opts.EntryPoint != 0 {
+		return memoryStreamAt(m, opts.EntryPoint)
+	}
+
+	if _, ok := m.segment(startAddr); !ok {
+		return nil, nil, errors.New("legacy BIOS memory range is not saved in core file")
+	}
+
+	return memoryStream(m, startAddr, endAddr)
+}
+
+// A physSegment is a PT_LOAD segment of a core file.
+type physSegment struct {
+	addr   int64
+	filesz int64
+	memsz  int64
+	r      io.ReaderAt
+}
+
+// A physMemory is an io.ReadSeeker over the physical address space saved in
+// a core file.  Memory which a segment does not hold in the file reads as
+// zero.  ReadAt fails at an address outside of all segments, where Read
+// reports io.EOF, so that a short read ends at a hole in the address space.
+type physMemory struct {
+	segs []physSegment
+	off  int64
+}
+
+// A notSavedError is returned by ReadAt for an address outside of all segments.
+type notSavedError int64
+
+func (e notSavedError) Error() string {
+	return fmt.Sprintf("physical address %#x is not saved in core file", int64(e))
+}
+
+// newPhysMemory maps the PT_LOAD segments of the ELF64 core file f.
+func newPhysMemory(f *elf.File) (*physMemory, error) {
+	if f.Class != elf.ELFCLASS64 {
+		return nil, fmt.Errorf("core file must be ELF64, but got: %s", f.Class)
+	}
+	if f.Type != elf.ET_CORE {
+		return nil, fmt.Errorf("ELF file must be a core file, but got: %s", f.Type)
+	}
+
+	m := &physMemory{}
+	for _, p := range f.Progs {
+		if p.Type != elf.PT_LOAD || p.Memsz == 0 {
+			continue
+		}
+
+		m.segs = append(m.segs, physSegment{
+			addr:   int64(p.Paddr),
+			filesz: int64(p.Filesz),
+			memsz:  int64(p.Memsz),
+			r:      p,
+		})
+	}
+	if len(m.segs) == 0 {
+		return nil, errors.New("no PT_LOAD segments found in core file")
+	}
+
+	// Segments may overlap: the kernel text segment of an x86_64 kdump
+	// vmcore lies within a System RAM segment.  Drop segments contained in
+	// another one, which hold the same memory, so that the ends of the
+	// remaining segments increase along with their starts as segment
+	// relies on.
+	sort.Slice(m.segs, func(i, j int) bool {
+		if m.segs[i].addr != m.segs[j].addr {
+			return m.segs[i].addr < m.segs[j].addr
+		}
+		return m.segs[i].memsz > m.segs[j].memsz
+	})
+
+	segs := m.segs[:1]
+	for _, s := range m.segs[1:] {
+		last := segs[len(segs)-1]
+		if s.addr+s.memsz <= last.addr+last.memsz {
+			continue
+		}
+		segs = append(segs, s)
+	}
+	m.segs = segs
+
+	return m, nil
+}
+
+// segment returns the segment holding addr.
+func (m *physMemory) segment(addr int64) (*physSegment, bool) {
+	i := sort.Search(len(m.segs), func(i int) bool {
+		return m.segs[i].addr+m.segs[i].memsz > addr
+	})
+	if i == len(m.segs) || m.segs[i].addr > addr {
+		return nil, false
+	}
+
+	return &m.segs[i], true
+}
+
+// ReadAt implements io.ReaderAt.
+func (m *physMemory) ReadAt(b []byte, off int64) (int, error) {
+	var n int
+	for n < len(b) {
+		addr := off + int64(n)
+		s, ok := m.segment(addr)
+		if !ok {
+			return n, notSavedError(addr)
+		}
+
+		rel := addr - s.addr
+		l := int64(len(b) - n)
+		if rest := s.memsz - rel; l > rest {
+			l = rest
+		}
+
+		if rel >= s.filesz {
+			// Memory not saved in the file, such as excluded zero pages.
+			for i := range b[n : n+int(l)] {
+				b[n+i] = 0
+			}
+			n += int(l)
+			continue
+		}
+
+		if rest := s.filesz - rel; l > rest {
+			l = rest
+		}
+		rn, err := s.r.ReadAt(b[n:n+int(l)], rel)
+		n += rn
+		if err != nil && !(err == io.EOF && int64(rn) == l) {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
+
+// Read implements io.Reader.
+func (m *physMemory) Read(b []byte) (int, error) {
+	n, err := m.ReadAt(b, m.off)
+	m.off += int64(n)
+
+	if _, ok := err.(notSavedError); ok {
+		if n > 0 {
+			return n, nil
+		}
+		return 0, io.EOF
+	}
+	return n, err
+}
+
+// Seek implements io.Seeker.  Only io.SeekStart and io.SeekCurrent are
+// supported, since physical memory has no end.
+func (m *physMemory) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += m.off
+	default:
+		return 0, fmt.Errorf("unsupported seek whence: %d", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("invalid seek offset: %d", offset)
+	}
+
+	m.off = offset
+	return offset, nil
+}
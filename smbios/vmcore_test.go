@@ -0,0 +1,248 @@
+package smbios
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// A coreSegment is a PT_LOAD segment written by writeCore.
+type coreSegment struct {
+	addr  uint64
+	memsz uint64
+	b     []byte
+}
+
+// writeCore writes an ELF64 core file holding segs to a temporary file.
+func writeCore(t *testing.T, typ elf.Type, segs []coreSegment) string {
+	t.Helper()
+
+	const (
+		ehsize    = 64
+		phentsize = 56
+	)
+
+	h := elf.Header64{
+		Type:      uint16(typ),
+		Machine:   uint16(elf.EM_X86_64),
+		Version:   uint32(elf.EV_CURRENT),
+		Phoff:     ehsize,
+		Ehsize:    ehsize,
+		Phentsize: phentsize,
+		Phnum:     uint16(len(segs)),
+	}
+	copy(h.Ident[:], elf.ELFMAG)
+	h.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	h.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	h.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.LittleEndian, h); err != nil {
+		t.Fatalf("failed to write ELF header: %v", err)
+	}
+
+	off := uint64(ehsize + phentsize*len(segs))
+	for _, s := range segs {
+		p := elf.Prog64{
+			Type:   uint32(elf.PT_LOAD),
+			Off:    off,
+			Paddr:  s.addr,
+			Filesz: uint64(len(s.b)),
+			Memsz:  s.memsz,
+		}
+		if err := binary.Write(&buf, binary.LittleEndian, p); err != nil {
+			t.Fatalf("failed to write program header: %v", err)
+		}
+		off += uint64(len(s.b))
+	}
+	for _, s := range segs {
+		buf.Write(s.b)
+	}
+
+	path := filepath.Join(t.TempDir(), "vmcore")
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write core file: %v", err)
+	}
+
+	return path
+}
+
+func TestOpenVMCore(t *testing.T) {
+	ss := []*Structure{
+		{
+			Header:    Header{Type: 1, Length: 5, Handle: 1},
+			Formatted: []byte{0x01},
+			Strings:   []string{"Dell Inc."},
+		},
+		{
+			Header: Header{Type: 127, Length: 4, Handle: 2},
+		},
+	}
+	table := encodeStructures(ss)
+
+	// The legacy BIOS memory range, with an entry point at its end so that
+	// reading the entry point runs into the hole after it.
+	legacy := make([]byte, 0x10000)
+	copy(legacy[0xffe0:], mustMarshalEntryPoint(&EntryPoint64Bit{
+		Major:                 3,
+		Minor:                 2,
+		StructureTableMaxSize: 0x1000,
+		StructureTableAddress: 0x7f000100,
+	}))
+
+	// Memory holding an entry point found through the EFI system table,
+	// and the table, which is only partly saved in the file.
+	high := make([]byte, 0x200)
+	copy(high, mustMarshalEntryPoint(&EntryPoint64Bit{
+		Major:                 3,
+		Minor:                 4,
+		StructureTableMaxSize: 0x1000,
+		StructureTableAddress: 0x7f000100,
+	}))
+	copy(high[0x100:], table)
+
+	path := writeCore(t, elf.ET_CORE, []coreSegment{
+		{addr: 0x7f000000, memsz: 0x2000, b: high},
+		{addr: startAddr, memsz: 0x10000, b: legacy},
+	})
+
+	tests := []struct {
+		name  string
+		opts  *VMCoreOptions
+		minor int
+	}{
+		{
+			name:  "legacy",
+			minor: 2,
+		},
+		{
+			name:  "explicit",
+			opts:  &VMCoreOptions{EntryPoint: 0x7f000000},
+			minor: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, ep, err := OpenVMCore(path, tt.opts)
+			if err != nil {
+				t.Fatalf("failed to open core file: %v", err)
+			}
+			defer rc.Close()
+
+			if _, minor, _ := ep.Version(); minor != tt.minor {
+				t.Fatalf("unexpected entry point version: 3.%d", minor)
+			}
+
+			got, err := NewDecoder(rc).Decode()
+			if err != nil {
+				t.Fatalf("failed to decode table: %v", err)
+			}
+			if diff := cmp.Diff(ss, got); diff != "" {
+				t.Fatalf("unexpected structures (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOpenVMCoreOverlappingSegments(t *testing.T) {
+	ss := []*Structure{{Header: Header{Type: 127, Length: 4, Handle: 1}}}
+	table := encodeStructures(ss)
+
+	// System RAM holding an entry point and the table above a kernel text
+	// segment which lies within it, as in an x86_64 kdump vmcore.
+	ram := make([]byte, 0x200)
+	copy(ram, mustMarshalEntryPoint(&EntryPoint64Bit{
+		Major:                 3,
+		StructureTableMaxSize: uint32(len(table)),
+		StructureTableAddress: 0x7f000180,
+	}))
+	copy(ram[0x180:], table)
+
+	path := writeCore(t, elf.ET_CORE, []coreSegment{
+		{addr: startAddr, memsz: 0x10000},
+		{addr: 0x7f000000, memsz: 0x2000, b: ram},
+		{addr: 0x7f000080, memsz: 0x40, b: ram[0x80:0xc0]},
+	})
+
+	rc, _, err := OpenVMCore(path, &VMCoreOptions{EntryPoint: 0x7f000000})
+	if err != nil {
+		t.Fatalf("failed to open core file: %v", err)
+	}
+	defer rc.Close()
+
+	got, err := NewDecoder(rc).Decode()
+	if err != nil {
+		t.Fatalf("failed to decode table: %v", err)
+	}
+	if diff := cmp.Diff(ss, got); diff != "" {
+		t.Fatalf("unexpected structures (-want +got):\n%s", diff)
+	}
+
+	// The layout of a kdump vmcore with 2 GiB of RAM.
+	f, err := elf.Open(writeCore(t, elf.ET_CORE, []coreSegment{
+		{addr: 0x100000, memsz: 0x7ff00000},
+		{addr: 0x1000000, memsz: 0x1e00000},
+	}))
+	if err != nil {
+		t.Fatalf("failed to open core file: %v", err)
+	}
+	defer f.Close()
+
+	m, err := newPhysMemory(f)
+	if err != nil {
+		t.Fatalf("failed to map core file: %v", err)
+	}
+	if s, ok := m.segment(0x30000000); !ok || s.addr != 0x100000 {
+		t.Fatalf("address in RAM above kernel text not found: %v, %v", s, ok)
+	}
+}
+
+func TestOpenVMCoreErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  elf.Type
+		segs []coreSegment
+		opts *VMCoreOptions
+	}{
+		{
+			name: "not a core file",
+			typ:  elf.ET_EXEC,
+			segs: []coreSegment{{addr: startAddr, memsz: 0x10000, b: make([]byte, 0x10000)}},
+		},
+		{
+			name: "no segments",
+			typ:  elf.ET_CORE,
+		},
+		{
+			name: "legacy range not saved",
+			typ:  elf.ET_CORE,
+			segs: []coreSegment{{addr: 0x100000, memsz: 0x1000}},
+		},
+		{
+			name: "no entry point",
+			typ:  elf.ET_CORE,
+			segs: []coreSegment{{addr: startAddr, memsz: 0x10000}},
+		},
+		{
+			name: "entry point address not saved",
+			typ:  elf.ET_CORE,
+			segs: []coreSegment{{addr: startAddr, memsz: 0x10000}},
+			opts: &VMCoreOptions{EntryPoint: 0x7f000000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeCore(t, tt.typ, tt.segs)
+			if _, _, err := OpenVMCore(path, tt.opts); err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}